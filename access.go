@@ -0,0 +1,127 @@
+package main
+
+import (
+	"cmp"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+)
+
+var (
+	errUnauthorized = errors.New("missing or invalid access token")
+	errForbidden    = errors.New("viewers cannot change the round: a facilitator token is required")
+)
+
+// What a caller may do in a room
+type role int
+
+const (
+	roleNone role = iota
+	roleViewer
+	roleFacilitator
+)
+
+func (r role) String() string {
+	switch r {
+	case roleFacilitator:
+		return "facilitator"
+	case roleViewer:
+		return "viewer"
+	default:
+		return "none"
+	}
+}
+
+// Access tokens of a room. Without any token the room is open to everyone;
+// without a viewer token, anyone can follow along but only facilitators can
+// change the round; with a viewer token only, the room is read-only.
+type access struct {
+	facilitator string
+	viewer      string
+}
+
+// Tokens of a room, from FACILITATOR_TOKEN and VIEWER_TOKEN
+func roomAccess(id string) access {
+	return access{facilitator: roomSetting(id, "FACILITATOR_TOKEN"), viewer: roomSetting(id, "VIEWER_TOKEN")}
+}
+
+// Tokens of the rooms API. Creating rooms takes ADMIN_TOKEN, falling back to
+// FACILITATOR_TOKEN so that no change is left open once it is set; listing
+// them takes the same tokens as following a room.
+func hubAccess() (admin, lobby access) {
+	facilitator := os.Getenv("FACILITATOR_TOKEN")
+	admin = access{facilitator: cmp.Or(os.Getenv("ADMIN_TOKEN"), facilitator)}
+	lobby = access{facilitator: facilitator, viewer: os.Getenv("VIEWER_TOKEN")}
+	return admin, lobby
+}
+
+// Environment variable specific to a room, with the room ID as a suffix
+// (e.g. FACILITATOR_TOKEN_PAYMENTS), falling back to the one for all rooms
+func roomSetting(id, name string) string {
+	if value := os.Getenv(name + roomSettingSuffix(id)); value != "" {
+		return value
+	}
+	return os.Getenv(name)
+}
+
+// Suffix of the environment variables of a room: "a-b" and "a_b" share it
+func roomSettingSuffix(id string) string {
+	return "_" + strings.ToUpper(strings.ReplaceAll(id, "-", "_"))
+}
+
+// Role granted by the token, and whether the token matched a configured one
+func (a access) roleOf(token string) (role, bool) {
+	switch {
+	case a.facilitator == "" && a.viewer == "":
+		return roleFacilitator, false
+	case a.facilitator != "" && tokenEquals(token, a.facilitator):
+		return roleFacilitator, true
+	case a.viewer != "" && tokenEquals(token, a.viewer):
+		return roleViewer, true
+	case a.viewer == "":
+		return roleViewer, false
+	default:
+		return roleNone, false
+	}
+}
+
+// Compare tokens in constant time, so that response times do not leak them
+func tokenEquals(given, expected string) bool {
+	return given != "" && subtle.ConstantTimeCompare([]byte(given), []byte(expected)) == 1
+}
+
+// Token of a request, from an "Authorization: Bearer" header or a "token"
+// query parameter (for shareable links and EventSource, which cannot set headers)
+func requestToken(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		if token, ok := strings.CutPrefix(auth, "Bearer "); ok {
+			return strings.TrimSpace(token)
+		}
+	}
+	return r.URL.Query().Get("token")
+}
+
+// Only let callers with at least the needed role through: others get a 401,
+// or a 403 if they have a valid token without enough rights
+func (a *access) require(need role, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		got, valid := a.roleOf(requestToken(r))
+		switch {
+		case got >= need:
+			next(w, r)
+		case valid:
+			writeError(w, errForbidden)
+		default:
+			w.Header().Set("WWW-Authenticate", `Bearer realm="daily-scrum-picker"`)
+			writeError(w, errUnauthorized)
+		}
+	}
+}
+
+// Role of the caller, so that clients only offer what they are allowed to do
+func (srv *server) handleRole(w http.ResponseWriter, r *http.Request) {
+	got, _ := srv.access.roleOf(requestToken(r))
+	writeJSON(w, http.StatusOK, map[string]string{"role": got.String()})
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rm3l/daily-scrum-picker/picker"
+)
+
+func TestAccess_RoleOf(t *testing.T) {
+	tests := []struct {
+		name          string
+		access        access
+		token         string
+		expected      role
+		expectedValid bool
+	}{
+		{name: "open room", access: access{}, expected: roleFacilitator},
+		{name: "facilitator", access: access{facilitator: "f", viewer: "v"}, token: "f", expected: roleFacilitator, expectedValid: true},
+		{name: "viewer", access: access{facilitator: "f", viewer: "v"}, token: "v", expected: roleViewer, expectedValid: true},
+		{name: "no token", access: access{facilitator: "f", viewer: "v"}, expected: roleNone},
+		{name: "wrong token", access: access{facilitator: "f", viewer: "v"}, token: "x", expected: roleNone},
+		{name: "public viewing", access: access{facilitator: "f"}, expected: roleViewer},
+		{name: "prefix of the token", access: access{facilitator: "secret"}, token: "sec", expected: roleViewer},
+		{name: "read-only without token", access: access{viewer: "v"}, expected: roleNone},
+		{name: "read-only as viewer", access: access{viewer: "v"}, token: "v", expected: roleViewer, expectedValid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, valid := tt.access.roleOf(tt.token)
+			if got != tt.expected || valid != tt.expectedValid {
+				t.Errorf("Expected %v (valid=%v), got %v (valid=%v)", tt.expected, tt.expectedValid, got, valid)
+			}
+		})
+	}
+}
+
+func TestServer_ViewerTokenOnly(t *testing.T) {
+	s := newTestSession(t, "Alice", "Bob")
+	setTestRound(t, s, s.TeamMembers()...)
+	srv := newServer(s)
+	srv.access = access{viewer: "viewer-token"}
+	ts := httptest.NewServer(srv.routes())
+	t.Cleanup(ts.Close)
+
+	var resp errorResponse
+	if status := doJSON(t, http.MethodPost, ts.URL+"/api/pick", "", &resp); status != http.StatusUnauthorized {
+		t.Errorf("Expected anonymous picks to be refused, got %d", status)
+	}
+	if status := doJSON(t, http.MethodPost, ts.URL+"/api/pick?token=viewer-token", "", &resp); status != http.StatusForbidden {
+		t.Errorf("Expected viewers not to pick, got %d", status)
+	}
+	if status := doJSON(t, http.MethodGet, ts.URL+"/api/status", "", &resp); status != http.StatusUnauthorized {
+		t.Errorf("Expected the status to require the viewer token, got %d", status)
+	}
+	var status picker.Status
+	if code := doJSON(t, http.MethodGet, ts.URL+"/api/status?token=viewer-token", "", &status); code != http.StatusOK {
+		t.Errorf("Expected viewers to follow the round, got %d", code)
+	}
+}
+
+func TestRoomAccess_FromEnvironment(t *testing.T) {
+	t.Setenv("FACILITATOR_TOKEN", "global")
+	t.Setenv("FACILITATOR_TOKEN_MOBILE_APP", "mobile")
+	t.Setenv("VIEWER_TOKEN", "")
+
+	if a := roomAccess("mobile-app"); a != (access{facilitator: "mobile"}) {
+		t.Errorf("Expected the room token to take precedence, got %+v", a)
+	}
+	if a := roomAccess("payments"); a != (access{facilitator: "global"}) {
+		t.Errorf("Expected the global token, got %+v", a)
+	}
+}
+
+func TestHubAccess_FromEnvironment(t *testing.T) {
+	t.Setenv("ADMIN_TOKEN", "")
+	t.Setenv("FACILITATOR_TOKEN", "facilitator")
+	t.Setenv("VIEWER_TOKEN", "viewer")
+
+	admin, lobby := hubAccess()
+	if admin != (access{facilitator: "facilitator"}) {
+		t.Errorf("Expected creating rooms to fall back to the facilitator token, got %+v", admin)
+	}
+	if lobby != (access{facilitator: "facilitator", viewer: "viewer"}) {
+		t.Errorf("Expected listing rooms to take the room tokens, got %+v", lobby)
+	}
+
+	t.Setenv("ADMIN_TOKEN", "admin")
+	if admin, _ := hubAccess(); admin != (access{facilitator: "admin"}) {
+		t.Errorf("Expected the admin token to take precedence, got %+v", admin)
+	}
+}
+
+func TestServer_AccessControl(t *testing.T) {
+	s := newTestSession(t, "Alice", "Bob")
+	setTestRound(t, s, s.TeamMembers()...)
+	srv := newServer(s)
+	srv.access = access{facilitator: "facilitator-token", viewer: "viewer-token"}
+	ts := httptest.NewServer(srv.routes())
+	t.Cleanup(ts.Close)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		token    string
+		expected int
+	}{
+		{name: "status without token", method: http.MethodGet, path: "/api/status", expected: http.StatusUnauthorized},
+		{name: "status as viewer", method: http.MethodGet, path: "/api/status", token: "viewer-token", expected: http.StatusOK},
+		{name: "status with query token", method: http.MethodGet, path: "/api/status?token=viewer-token", expected: http.StatusOK},
+		{name: "pick without token", method: http.MethodPost, path: "/api/pick", expected: http.StatusUnauthorized},
+		{name: "pick with wrong token", method: http.MethodPost, path: "/api/pick", token: "nope", expected: http.StatusUnauthorized},
+		{name: "pick as viewer", method: http.MethodPost, path: "/api/pick", token: "viewer-token", expected: http.StatusForbidden},
+		{name: "reset as viewer", method: http.MethodPost, path: "/api/reset", token: "viewer-token", expected: http.StatusForbidden},
+		{name: "pick as facilitator", method: http.MethodPost, path: "/api/pick", token: "facilitator-token", expected: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, ts.URL+tt.path, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			if err := resp.Body.Close(); err != nil {
+				t.Logf("Warning: failed to close response body: %v", err)
+			}
+
+			if resp.StatusCode != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, resp.StatusCode)
+			}
+			if resp.StatusCode == http.StatusUnauthorized && resp.Header.Get("WWW-Authenticate") == "" {
+				t.Error("Expected a WWW-Authenticate header with the 401")
+			}
+		})
+	}
+
+	var role map[string]string
+	doJSON(t, http.MethodGet, ts.URL+"/api/role?token=viewer-token", "", &role)
+	if role["role"] != "viewer" {
+		t.Errorf("Expected the viewer role, got %v", role)
+	}
+
+	// The web UI itself is public, the data it loads is not
+	resp, err := http.Get(ts.URL + "/")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if err := resp.Body.Close(); err != nil {
+		t.Logf("Warning: failed to close response body: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected the web UI to be served without a token, got %d", resp.StatusCode)
+	}
+}
+
+func TestHub_AdminToken(t *testing.T) {
+	h, ts := newTestHub(t, nil)
+	h.admin = access{facilitator: "admin-token"}
+
+	body := `{"id": "mobile", "members": ["Eve"]}`
+	var resp errorResponse
+	if status := doJSON(t, http.MethodPost, ts.URL+"/api/rooms", body, &resp); status != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without the admin token, got %d", status)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, ts.URL+"/api/rooms", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer admin-token")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if err := res.Body.Close(); err != nil {
+		t.Logf("Warning: failed to close response body: %v", err)
+	}
+	if res.StatusCode != http.StatusCreated {
+		t.Errorf("Expected 201 with the admin token, got %d", res.StatusCode)
+	}
+}
+
+func TestHub_ListRoomsWithViewerToken(t *testing.T) {
+	h, ts := newTestHub(t, map[string]string{"payments.txt": "Alice\n"})
+	h.lobby = access{facilitator: "facilitator-token", viewer: "viewer-token"}
+
+	var resp errorResponse
+	for _, path := range []string{"/api/rooms", "/"} {
+		if status := doJSON(t, http.MethodGet, ts.URL+path, "", &resp); status != http.StatusUnauthorized {
+			t.Errorf("GET %s: expected 401 without a token, got %d", path, status)
+		}
+	}
+	var rooms map[string][]roomInfo
+	if status := doJSON(t, http.MethodGet, ts.URL+"/api/rooms?token=viewer-token", "", &rooms); status != http.StatusOK || len(rooms["rooms"]) != 1 {
+		t.Errorf("Expected the viewer to list rooms, got %d %v", status, rooms)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"time"
+)
+
+const defaultAnimateDuration = 2 * time.Second
+
+// Delay between the first frames of the reveal; it grows until the wheel
+// stops on the pick
+const (
+	revealFirstDelay = 30 * time.Millisecond
+	revealSlowdown   = 1.15
+)
+
+// Slot-machine reveal of a random pick: names cycle rapidly on a single line,
+// slowing down until they settle on the pick
+type reveal struct {
+	w        io.Writer
+	duration time.Duration
+	emoji    bool
+	// Replaced in tests
+	sleep func(time.Duration)
+}
+
+func newReveal(w io.Writer, duration time.Duration, emoji bool) *reveal {
+	return &reveal{w: w, duration: duration, emoji: emoji, sleep: time.Sleep}
+}
+
+// Spin through the candidates of a pick (including the pick), then clear the
+// line for the outcome. There is nothing to reveal with a single candidate.
+func (r *reveal) spin(candidates []string, pick string) {
+	if len(candidates) < 2 {
+		return
+	}
+
+	order := rand.Perm(len(candidates))
+	var elapsed time.Duration
+	delay := revealFirstDelay
+	for i := 0; elapsed+delay < r.duration; i++ {
+		r.frame(candidates[order[i%len(order)]])
+		r.sleep(delay)
+		elapsed += delay
+		delay = time.Duration(float64(delay) * revealSlowdown)
+	}
+	r.frame(pick)
+	r.sleep(max(r.duration-elapsed, 0))
+	_, _ = fmt.Fprint(r.w, "\r\033[K")
+}
+
+func (r *reveal) frame(name string) {
+	_, _ = fmt.Fprintf(r.w, "\r\033[K%s%s", icon(r.emoji, "🎡 "), name)
+}
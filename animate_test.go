@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRevealSettlesOnPick(t *testing.T) {
+	var buf bytes.Buffer
+	var slept []time.Duration
+	r := &reveal{w: &buf, duration: time.Second, sleep: func(d time.Duration) { slept = append(slept, d) }}
+
+	r.spin([]string{"Alice", "Bob", "Carol"}, "Bob")
+
+	frames := strings.Split(strings.TrimSuffix(buf.String(), "\r\033[K"), "\r\033[K")[1:]
+	if len(frames) < 10 {
+		t.Fatalf("Expected the wheel to spin through many names, got %q", frames)
+	}
+	for _, frame := range frames {
+		if !slices.Contains([]string{"Alice", "Bob", "Carol"}, frame) {
+			t.Errorf("Unexpected frame %q", frame)
+		}
+	}
+	if last := frames[len(frames)-1]; last != "Bob" {
+		t.Errorf("Expected the wheel to stop on Bob, got %q", last)
+	}
+	if !strings.HasSuffix(buf.String(), "\r\033[K") {
+		t.Error("Expected the line to be cleared for the outcome")
+	}
+
+	var total time.Duration
+	for _, d := range slept {
+		total += d
+	}
+	if total != time.Second {
+		t.Errorf("Expected the reveal to last 1s, got %s", total)
+	}
+	if slept[0] >= slept[len(slept)-2] {
+		t.Errorf("Expected the wheel to slow down, got delays %v", slept)
+	}
+}
+
+func TestRevealSkipsSingleCandidate(t *testing.T) {
+	var buf bytes.Buffer
+	r := &reveal{w: &buf, duration: time.Second, sleep: func(time.Duration) { t.Error("Unexpected sleep") }}
+	r.spin([]string{"Alice"}, "Alice")
+	if buf.Len() != 0 {
+		t.Errorf("Expected no reveal, got %q", buf.String())
+	}
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const defaultAnnounceTimeout = 10 * time.Second
+
+// Text-to-speech engines, in order of preference when none is configured
+var speechEngines = []string{"espeak-ng", "espeak", "say"}
+
+// Engine ringing the terminal bell, when no text-to-speech engine is available
+const engineBell = "bell"
+
+// Default phrase, in the language of the messages
+const defaultPhrase = `{{tr "announce" .Name}}`
+
+// How picks are announced, as set in the config file, e.g.
+//
+//	{"engine": "say", "phrase": "{{.Name}}, you're up!"}
+type announceConfig struct {
+	// espeak-ng, espeak, say or bell; the first engine installed if empty
+	Engine string `json:"engine,omitempty"`
+	// Command announcing the phrase instead of an engine, run by the shell
+	// with the phrase on its standard input and in SCRUM_PHRASE
+	Command string `json:"command,omitempty"`
+	// Template of the phrase, with the same data as the pick message
+	Phrase  string   `json:"phrase,omitempty"`
+	Timeout duration `json:"timeout,omitempty"`
+}
+
+func (c announceConfig) validate() error {
+	if c.Engine != "" && c.Engine != engineBell && !slices.Contains(speechEngines, c.Engine) {
+		return fmt.Errorf("unknown engine '%s' (expected %s or %s)", c.Engine, strings.Join(speechEngines, ", "), engineBell)
+	}
+	if c.Engine != "" && c.Command != "" {
+		return errors.New("engine and command cannot both be set")
+	}
+	_, err := parsePhrase(c.Phrase, english)
+	return err
+}
+
+func parsePhrase(phrase string, lang *language) (*template.Template, error) {
+	if phrase == "" {
+		phrase = defaultPhrase
+	}
+	tmpl, err := template.New("phrase").Funcs(template.FuncMap{
+		"tr":     lang.T,
+		"plural": lang.plural,
+		"join":   strings.Join,
+	}).Parse(phrase)
+	if err != nil {
+		return nil, fmt.Errorf("invalid phrase: %w", err)
+	}
+	if err := tmpl.Execute(io.Discard, sampleData("pick")); err != nil {
+		return nil, fmt.Errorf("invalid phrase: %w", err)
+	}
+	return tmpl, nil
+}
+
+// Announcer tells the room who speaks next, e.g. by saying their name
+type Announcer interface {
+	Announce(ctx context.Context, phrase string) error
+}
+
+// Announcer to use for the config: the command or engine configured, else
+// the first engine installed, else the terminal bell rung on console
+func newAnnouncer(cfg announceConfig, lang *language, console io.Writer) Announcer {
+	if cfg.Command != "" {
+		return &commandAnnouncer{command: cfg.Command}
+	}
+	if cfg.Engine == engineBell {
+		return &bellAnnouncer{w: console}
+	}
+
+	engines := speechEngines
+	if cfg.Engine != "" {
+		engines = []string{cfg.Engine}
+	}
+	for _, engine := range engines {
+		if path, err := exec.LookPath(engine); err == nil {
+			return newSpeechAnnouncer(engine, path, lang)
+		}
+	}
+	if cfg.Engine != "" {
+		log.Printf("Warning: %s is not installed, ringing the bell instead", cfg.Engine)
+	}
+	return &bellAnnouncer{w: console}
+}
+
+// Says the phrase with a text-to-speech engine
+type speechAnnouncer struct {
+	path string
+	// Arguments before the phrase
+	args []string
+}
+
+func newSpeechAnnouncer(engine, path string, lang *language) *speechAnnouncer {
+	a := &speechAnnouncer{path: path}
+	// espeak has a voice per language, while say voices are named
+	if engine != "say" {
+		a.args = []string{"-v", lang.code}
+	}
+	return a
+}
+
+func (a *speechAnnouncer) Announce(ctx context.Context, phrase string) error {
+	// Names starting with a dash must not be taken for options
+	return runCommand(exec.CommandContext(ctx, a.path, append(slices.Clone(a.args), "--", phrase)...))
+}
+
+// Runs a command of the config file, e.g. another text-to-speech engine
+type commandAnnouncer struct {
+	command string
+}
+
+func (a *commandAnnouncer) Announce(ctx context.Context, phrase string) error {
+	cmd := shellCommand(ctx, a.command)
+	cmd.Env = append(os.Environ(), "SCRUM_PHRASE="+phrase)
+	cmd.Stdin = strings.NewReader(phrase)
+	return runCommand(cmd)
+}
+
+// Rings the terminal bell
+type bellAnnouncer struct {
+	w io.Writer
+}
+
+func (a *bellAnnouncer) Announce(ctx context.Context, phrase string) error {
+	_, err := fmt.Fprint(a.w, "\a")
+	return err
+}
+
+// Announces picks in the background, one at a time, so that speaking never
+// holds up the interactive loop
+type announcements struct {
+	announcer Announcer
+	phrase    *template.Template
+	timeout   time.Duration
+	queue     chan string
+	done      chan struct{}
+}
+
+func newAnnouncements(announcer Announcer, phrase *template.Template, timeout time.Duration) *announcements {
+	if timeout <= 0 {
+		timeout = defaultAnnounceTimeout
+	}
+	a := &announcements{
+		announcer: announcer,
+		phrase:    phrase,
+		timeout:   timeout,
+		queue:     make(chan string, 1),
+		done:      make(chan struct{}),
+	}
+	go a.run()
+	return a
+}
+
+// Announce picks of the session as configured, until the session is closed
+func (s *session) announcePicks(cfg announceConfig) error {
+	phrase, err := parsePhrase(cfg.Phrase, s.lang)
+	if err != nil {
+		return err
+	}
+	a := newAnnouncements(newAnnouncer(cfg, s.lang, s.console), phrase, time.Duration(cfg.Timeout))
+	s.announcements = a
+	s.onClose(func() {
+		a.close()
+		select {
+		case <-a.done:
+		case <-time.After(a.timeout):
+			log.Printf("Warning: gave up waiting for the announcement")
+		}
+	})
+	return nil
+}
+
+func (a *announcements) announce(data pickData) {
+	var phrase strings.Builder
+	if err := a.phrase.Execute(&phrase, data); err != nil {
+		log.Printf("Warning: failed to render the announcement: %v", err)
+		return
+	}
+	// An announcement still waiting is stale once someone else is picked
+	select {
+	case <-a.queue:
+	default:
+	}
+	a.queue <- phrase.String()
+}
+
+// Stop accepting picks; done is closed once the pending one is announced
+func (a *announcements) close() {
+	close(a.queue)
+}
+
+func (a *announcements) run() {
+	defer close(a.done)
+	for phrase := range a.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), a.timeout)
+		err := a.announcer.Announce(ctx, phrase)
+		if ctx.Err() == context.DeadlineExceeded {
+			err = fmt.Errorf("timed out after %s", a.timeout)
+		}
+		if err != nil {
+			log.Printf("Warning: failed to announce '%s': %v", phrase, err)
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+)
+
+// Records the phrases it is asked to announce
+type fakeAnnouncer struct {
+	phrases []string
+}
+
+func (a *fakeAnnouncer) Announce(ctx context.Context, phrase string) error {
+	a.phrases = append(a.phrases, phrase)
+	return nil
+}
+
+func TestAnnouncePicks(t *testing.T) {
+	s := newTestSession(t, "Alice", "Bob")
+	s.lang = french
+	phrase, err := parsePhrase("", s.lang)
+	if err != nil {
+		t.Fatalf("parsePhrase failed: %v", err)
+	}
+	announcer := &fakeAnnouncer{}
+	s.announcements = newAnnouncements(announcer, phrase, time.Second)
+
+	manualPick(s, "Bob")
+	s.announcements.close()
+	<-s.announcements.done
+
+	if !slices.Equal(announcer.phrases, []string{"Bob, à toi !"}) {
+		t.Errorf("Expected Bob to be announced in French, got %q", announcer.phrases)
+	}
+}
+
+func TestAnnounceConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  announceConfig
+		wantErr bool
+	}{
+		{name: "default", config: announceConfig{}},
+		{name: "phrase", config: announceConfig{Engine: "say", Phrase: "{{.Name}}, speaker {{.Position}} of round {{.Round}}"}},
+		{name: "unknown engine", config: announceConfig{Engine: "festival"}, wantErr: true},
+		{name: "engine and command", config: announceConfig{Engine: "say", Command: "piper"}, wantErr: true},
+		{name: "invalid phrase", config: announceConfig{Phrase: "{{.Nmae}}"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Expected error: %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNewAnnouncer(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake engines are shell scripts")
+	}
+	dir := t.TempDir()
+	t.Setenv("PATH", dir)
+
+	if _, ok := newAnnouncer(announceConfig{}, english, io.Discard).(*bellAnnouncer); !ok {
+		t.Error("Expected the bell without any text-to-speech engine")
+	}
+
+	output := filepath.Join(dir, "spoken.txt")
+	engine := "#!/bin/sh\necho \"$@\" > " + output + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "espeak"), []byte(engine), 0o755); err != nil {
+		t.Fatalf("Failed to write the fake engine: %v", err)
+	}
+	announcer := newAnnouncer(announceConfig{}, french, io.Discard)
+	if err := announcer.Announce(context.Background(), "Bob, à toi !"); err != nil {
+		t.Fatalf("Announce failed: %v", err)
+	}
+	if spoken, _ := os.ReadFile(output); string(spoken) != "-v fr -- Bob, à toi !\n" {
+		t.Errorf("Expected espeak to speak French, got %q", spoken)
+	}
+	if err := announcer.Announce(context.Background(), "-x, à toi !"); err != nil {
+		t.Fatalf("Announce failed: %v", err)
+	}
+	if spoken, _ := os.ReadFile(output); string(spoken) != "-v fr -- -x, à toi !\n" {
+		t.Errorf("Expected a name starting with a dash to be spoken, got %q", spoken)
+	}
+}
+
+func TestCommandAnnouncer(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell command")
+	}
+	output := filepath.Join(t.TempDir(), "spoken.txt")
+	announcer := &commandAnnouncer{command: `printf '%s|' "$SCRUM_PHRASE" > ` + output + ` && cat >> ` + output}
+	if err := announcer.Announce(context.Background(), "Alice, you're up!"); err != nil {
+		t.Fatalf("Announce failed: %v", err)
+	}
+	if spoken, _ := os.ReadFile(output); string(spoken) != "Alice, you're up!|Alice, you're up!" {
+		t.Errorf("Expected the phrase in SCRUM_PHRASE and on stdin, got %q", spoken)
+	}
+}
+
+func TestBellAnnouncer(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (&bellAnnouncer{w: &buf}).Announce(context.Background(), "Alice"); err != nil {
+		t.Fatalf("Announce failed: %v", err)
+	}
+	if buf.String() != "\a" {
+		t.Errorf("Expected a bell, got %q", buf.String())
+	}
+}
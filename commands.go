@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var errUnknownCommand = errors.New("unknown command")
+
+// Commands shared by the CLI, as subcommands, and the buffered-mode REPL.
+// getSession is only called once the arguments have been parsed.
+func newSessionCommands(getSession func() *session) []*cobra.Command {
+	pickCmd := &cobra.Command{
+		Use:     "pick [name|number]",
+		Aliases: []string{"p", "manual", "m"},
+		Short:   "Pick the next person, or a specific remaining member",
+		Run: func(cmd *cobra.Command, args []string) {
+			s := getSession()
+			if len(args) == 0 {
+				pickNextPerson(s)
+				return
+			}
+			manualPick(s, strings.Join(args, " "))
+		},
+	}
+
+	skipCmd := &cobra.Command{
+		Use:     "skip [name|number]",
+		Aliases: []string{"k"},
+		Short:   "Move someone (the next in line by default) to the end of the round",
+		Run: func(cmd *cobra.Command, args []string) {
+			skipPerson(getSession(), strings.Join(args, " "))
+		},
+	}
+
+	absentCmd := &cobra.Command{
+		Use:     "absent <name|number>",
+		Aliases: []string{"a"},
+		Short:   "Remove an absent member from this round",
+		Args:    cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			markAbsent(getSession(), strings.Join(args, " "))
+		},
+	}
+
+	var jsonOutput bool
+	statusCmd := &cobra.Command{
+		Use:     "status",
+		Aliases: []string{"s"},
+		Short:   "Show current status and remaining team members",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := getSession()
+			if !jsonOutput {
+				showStatus(s)
+				return nil
+			}
+			status, err := s.Status()
+			if err != nil {
+				return err
+			}
+			data, err := json.MarshalIndent(status, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+			return nil
+		},
+	}
+	statusCmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the status as JSON")
+
+	undoCmd := &cobra.Command{
+		Use:     "undo",
+		Aliases: []string{"u"},
+		Short:   "Undo the last pick, skip or absence",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			undoLast(getSession())
+		},
+	}
+
+	resetCmd := &cobra.Command{
+		Use:     "reset",
+		Aliases: []string{"r"},
+		Short:   "Reset state and start over with all team members",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			resetState(getSession())
+		},
+	}
+
+	return []*cobra.Command{pickCmd, skipCmd, absentCmd, statusCmd, undoCmd, resetCmd}
+}
+
+// Commands of the buffered-mode REPL only: guests are dropped when the
+// meeting ends, which a one-shot subcommand would not outlive
+func newMeetingCommands(getSession func() *session) []*cobra.Command {
+	runAddGuest := func(cmd *cobra.Command, args []string) {
+		addGuest(getSession(), strings.Join(args, " "))
+	}
+	guestCmd := &cobra.Command{
+		Use:     "guest <name>",
+		Aliases: []string{"g"},
+		Short:   "Add a guest to the current round only",
+		Args:    cobra.MinimumNArgs(1),
+		Run:     runAddGuest,
+	}
+	addCmd := &cobra.Command{
+		Use:   "add",
+		Short: "Add participants to the current round",
+	}
+	addCmd.AddCommand(&cobra.Command{
+		Use:   "guest <name>",
+		Short: "Add a guest to the current round only",
+		Args:  cobra.MinimumNArgs(1),
+		Run:   runAddGuest,
+	})
+	return []*cobra.Command{guestCmd, addCmd}
+}
+
+// Run a command typed in buffered mode, e.g. `pick Alice` or `status --json`,
+// through the same cobra commands as the CLI
+func runSessionCommand(s *session, args []string) error {
+	root := &cobra.Command{
+		Use:           "daily-scrum-picker",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	getSession := func() *session { return s }
+	root.AddCommand(newSessionCommands(getSession)...)
+	root.AddCommand(newMeetingCommands(getSession)...)
+
+	// Command names are case-insensitive, like the single-letter shortcuts
+	args = append([]string{strings.ToLower(args[0])}, args[1:]...)
+	if cmd, _, err := root.Find(args); err != nil || cmd == root {
+		return errUnknownCommand
+	}
+	root.SetArgs(args)
+	return root.Execute()
+}
+
+// Split a command line into words, keeping quoted strings together
+func splitCommandLine(line string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inWord := false
+	var quote rune
+
+	for _, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t':
+			if inWord {
+				args = append(args, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inWord {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
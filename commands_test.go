@@ -0,0 +1,92 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSplitCommandLine(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected []string
+		wantErr  bool
+	}{
+		{line: "pick", expected: []string{"pick"}},
+		{line: "  skip   2 ", expected: []string{"skip", "2"}},
+		{line: `add guest "Carol Ann"`, expected: []string{"add", "guest", "Carol Ann"}},
+		{line: `absent 'Bob Smith'`, expected: []string{"absent", "Bob Smith"}},
+		{line: "status --json", expected: []string{"status", "--json"}},
+		{line: `pick ""`, expected: []string{"pick", ""}},
+		{line: `pick "Alice`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		result, err := splitCommandLine(tt.line)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("splitCommandLine(%q) = %q; expected an error", tt.line, result)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("splitCommandLine(%q) failed: %v", tt.line, err)
+			continue
+		}
+		if !slices.Equal(result, tt.expected) {
+			t.Errorf("splitCommandLine(%q) = %q; want %q", tt.line, result, tt.expected)
+		}
+	}
+}
+
+func TestRunSessionCommand(t *testing.T) {
+	s := newTestSession(t, "Alice", "Bob", "Charlie", "Diana")
+	setTestRound(t, s, "Alice", "Bob", "Charlie", "Diana")
+
+	commands := [][]string{
+		{"pick", "char"},          // Alice, Bob, Diana
+		{"SKIP"},                  // Bob, Diana, Alice
+		{"skip", "2"},             // Bob, Alice, Diana
+		{"absent", "Bob"},         // Alice, Diana
+		{"add", "guest", "Carol"}, // Alice, Diana + Carol somewhere
+	}
+	for _, args := range commands {
+		if err := runSessionCommand(s, args); err != nil {
+			t.Fatalf("runSessionCommand(%q) failed: %v", args, err)
+		}
+	}
+
+	remaining := testRemaining(t, s)
+	withoutGuest := slices.DeleteFunc(slices.Clone(remaining), func(name string) bool { return name == "Carol" })
+	if !slices.Equal(withoutGuest, []string{"Alice", "Diana"}) || len(remaining) != 3 {
+		t.Errorf("Unexpected remaining members %v", remaining)
+	}
+
+	entries, err := s.History()
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+	var actions []string
+	for _, entry := range entries {
+		actions = append(actions, entry.Action+":"+entry.Name)
+	}
+	expected := "manual:Charlie skip:Alice skip:Diana absent:Bob"
+	if strings.Join(actions, " ") != expected {
+		t.Errorf("Unexpected history %q; want %q", strings.Join(actions, " "), expected)
+	}
+}
+
+func TestRunSessionCommand_Errors(t *testing.T) {
+	s := newTestSession(t, "Alice")
+
+	if err := runSessionCommand(s, []string{"dance"}); !errors.Is(err, errUnknownCommand) {
+		t.Errorf("Expected errUnknownCommand, got %v", err)
+	}
+	if err := runSessionCommand(s, []string{"absent"}); err == nil {
+		t.Error("Expected an error when absent is missing a name")
+	}
+	if err := runSessionCommand(s, []string{"status", "--bogus"}); err == nil {
+		t.Error("Expected an error for an unknown flag")
+	}
+}
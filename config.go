@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+var configFileFlag string
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&configFileFlag, "config", "", "Path to a JSON configuration file (overrides CONFIG_FILE environment variable)")
+}
+
+// Settings that do not fit in flags, read from a JSON file, e.g.
+//
+//	{
+//	  "webhooks": [
+//	    {"url": "https://hooks.slack.com/services/...", "format": "slack"}
+//	  ],
+//	  "hooks": [
+//	    {"command": "say \"$SCRUM_NAME\"", "events": ["pick"]}
+//	  ],
+//	  "theme": "solarized-dark",
+//	  "announce": {"engine": "espeak-ng"}
+//	}
+type config struct {
+	Webhooks []webhookConfig `json:"webhooks"`
+	Hooks    []hookConfig    `json:"hooks"`
+	// Built-in color theme of text output
+	Theme string `json:"theme"`
+	// Theme file overriding colors of the built-in theme, relative to the
+	// config file
+	ThemeFile string `json:"themeFile"`
+	// Templates overriding messages of text output, e.g.
+	// {"pick": "🎤 Over to you, {{.Name}}!"}
+	Messages map[string]string `json:"messages"`
+	// Announcement of picks, e.g. by text-to-speech; also enabled by the
+	// --announce flag
+	Announce *announceConfig `json:"announce"`
+
+	// Colors resolved from Theme and ThemeFile
+	theme theme
+}
+
+// Get the config file path from the flag, then the environment; none by default
+func getConfigFile(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	return os.Getenv("CONFIG_FILE")
+}
+
+func loadConfig(configFile string) (config, error) {
+	var cfg config
+	if configFile == "" {
+		return cfg, nil
+	}
+
+	file, err := os.Open(configFile)
+	if err != nil {
+		return cfg, err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			fmt.Printf("Warning: failed to close file: %v\n", err)
+		}
+	}()
+
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&cfg); err != nil {
+		return cfg, fmt.Errorf("invalid config file '%s': %w", configFile, err)
+	}
+	for i, webhook := range cfg.Webhooks {
+		if err := webhook.validate(); err != nil {
+			return cfg, fmt.Errorf("invalid config file '%s': webhook #%d: %w", configFile, i+1, err)
+		}
+	}
+	for i, hook := range cfg.Hooks {
+		if err := hook.validate(); err != nil {
+			return cfg, fmt.Errorf("invalid config file '%s': hook #%d: %w", configFile, i+1, err)
+		}
+	}
+
+	if cfg.Announce != nil {
+		if err := cfg.Announce.validate(); err != nil {
+			return cfg, fmt.Errorf("invalid config file '%s': announce: %w", configFile, err)
+		}
+	}
+
+	themeFile := cfg.ThemeFile
+	if themeFile != "" && !filepath.IsAbs(themeFile) {
+		themeFile = filepath.Join(filepath.Dir(configFile), themeFile)
+	}
+	if cfg.theme, err = loadTheme(cfg.Theme, themeFile); err != nil {
+		return cfg, fmt.Errorf("invalid config file '%s': %w", configFile, err)
+	}
+	if _, err := parseMessages(cfg.Messages, messageFuncs(&textRenderer{lang: english})); err != nil {
+		return cfg, fmt.Errorf("invalid config file '%s': %w", configFile, err)
+	}
+	return cfg, nil
+}
+
+// Load the config file, exiting with a helpful message if it is invalid
+func loadConfigOrExit() config {
+	cfg, err := loadConfig(getConfigFile(configFileFlag))
+	if err != nil {
+		fmt.Printf("Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	return cfg
+}
+
+// Duration written as a string in the config file, e.g. "5s" or "1m30s"
+type duration time.Duration
+
+func (d *duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("durations are strings such as \"5s\": %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = duration(parsed)
+	return nil
+}
+
+func (d duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
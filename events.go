@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/rm3l/daily-scrum-picker/picker"
+)
+
+// Check that each event is one of the types emitted by the picker
+func validateEventTypes(events []string) error {
+	for _, event := range events {
+		switch event {
+		case picker.EventPick, picker.EventSkip, picker.EventAbsent, picker.EventUndo, picker.EventReset,
+			picker.EventRoundComplete, picker.EventMeetingEnd:
+		default:
+			return fmt.Errorf("unknown event '%s'", event)
+		}
+	}
+	return nil
+}
+
+// Register a function to run when the session is closed, e.g. to flush
+// pending notifications
+func (s *session) onClose(closer func()) {
+	s.closers = append(s.closers, closer)
+}
+
+// Release what the session holds once it is no longer used
+func (s *session) close() {
+	for _, closer := range s.closers {
+		closer()
+	}
+	s.closers = nil
+}
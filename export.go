@@ -0,0 +1,306 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rm3l/daily-scrum-picker/picker"
+)
+
+const dateLayout = "2006-01-02"
+
+var (
+	exportDaysFlag     int
+	exportStartFlag    string
+	exportWeekdaysFlag string
+	exportHolidayFlags []string
+	exportAwayFlags    []string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the upcoming rotation",
+}
+
+var exportICSCmd = &cobra.Command{
+	Use:   "ics [file]",
+	Short: "Write the facilitators of the next meeting days as an iCalendar file (to stdout by default)",
+	Long: `Write the facilitators of the next meeting days as an iCalendar file, one
+all-day event per meeting day, taking turns in the order of the current round.
+Later rounds are not shuffled until they start, so their events are tentative.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runExportICS,
+}
+
+func init() {
+	exportICSCmd.Flags().IntVar(&exportDaysFlag, "days", 10, "Number of meeting days to export")
+	exportICSCmd.Flags().StringVar(&exportStartFlag, "start", "", "First day to consider, as YYYY-MM-DD (today by default)")
+	exportICSCmd.Flags().StringVar(&exportWeekdaysFlag, "weekdays", "mon,tue,wed,thu,fri", "Days of the week with a meeting")
+	exportICSCmd.Flags().StringArrayVar(&exportHolidayFlags, "holiday", nil, "Day without a meeting, as YYYY-MM-DD or a YYYY-MM-DD..YYYY-MM-DD range (can be repeated)")
+	exportICSCmd.Flags().StringArrayVar(&exportAwayFlags, "away", nil, "Member away on some days, e.g. Bob=2026-10-21..2026-10-23 (can be repeated)")
+	exportCmd.AddCommand(exportICSCmd)
+	rootCmd.AddCommand(exportCmd)
+}
+
+// Inclusive range of days
+type dateRange struct {
+	from, to time.Time
+}
+
+func (r dateRange) contains(day time.Time) bool {
+	return !day.Before(r.from) && !day.After(r.to)
+}
+
+// Parse a day (YYYY-MM-DD) or a range of days (YYYY-MM-DD..YYYY-MM-DD)
+func parseDateRange(s string) (dateRange, error) {
+	fromText, toText, isRange := strings.Cut(strings.TrimSpace(s), "..")
+	from, err := time.ParseInLocation(dateLayout, fromText, time.Local)
+	if err != nil {
+		return dateRange{}, fmt.Errorf("invalid date '%s' (expected YYYY-MM-DD)", fromText)
+	}
+	if !isRange {
+		return dateRange{from: from, to: from}, nil
+	}
+	to, err := time.ParseInLocation(dateLayout, toText, time.Local)
+	if err != nil {
+		return dateRange{}, fmt.Errorf("invalid date '%s' (expected YYYY-MM-DD)", toText)
+	}
+	if to.Before(from) {
+		return dateRange{}, fmt.Errorf("invalid range '%s': it ends before it starts", s)
+	}
+	return dateRange{from: from, to: to}, nil
+}
+
+// Parse comma-separated days of the week, e.g. mon,wed,fri
+func parseWeekdays(s string) ([]time.Weekday, error) {
+	var weekdays []time.Weekday
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		found := false
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			// Full names or abbreviations, e.g. monday or mon
+			if len(name) >= 3 && strings.HasPrefix(strings.ToLower(day.String()), name) {
+				weekdays = append(weekdays, day)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown day of the week '%s' (expected e.g. mon,tue,wed,thu,fri)", name)
+		}
+	}
+	return weekdays, nil
+}
+
+// Meeting days and who can attend them, to plan the rotation
+type meetingCalendar struct {
+	start    time.Time
+	days     int
+	weekdays []time.Weekday
+	holidays []dateRange
+	// Days each member is away
+	away map[string][]dateRange
+}
+
+func (c meetingCalendar) isMeetingDay(day time.Time) bool {
+	return slices.Contains(c.weekdays, day.Weekday()) &&
+		!slices.ContainsFunc(c.holidays, func(r dateRange) bool { return r.contains(day) })
+}
+
+func (c meetingCalendar) isAway(name string, day time.Time) bool {
+	return slices.ContainsFunc(c.away[name], func(r dateRange) bool { return r.contains(day) })
+}
+
+// Facilitator of a meeting day
+type rotationDay struct {
+	Date time.Time
+	Name string
+	// In a round that has not been shuffled yet
+	Tentative bool
+}
+
+// How far ahead to look for meeting days, e.g. when everyone is away
+const maxPlannedDays = 5 * 366
+
+// Plan who facilitates each meeting day: the next in line who is not away
+// takes the day, while those away keep their place. When all those left in
+// the round are away, or once it is over, the next round starts, shuffled by
+// shuffle. Its picks are tentative, as the actual shuffle happens later.
+func planRotation(current, team []string, c meetingCalendar, shuffle func([]string)) []rotationDay {
+	var plan []rotationDay
+	// The first confirmed names of the queue are from the current round
+	queue, confirmed := slices.Clone(current), len(current)
+	for day, i := c.start, 0; len(plan) < c.days && i < maxPlannedDays; day, i = day.AddDate(0, 0, 1), i+1 {
+		available := func(name string) bool { return !c.isAway(name, day) }
+		if !c.isMeetingDay(day) || !slices.ContainsFunc(team, available) {
+			continue
+		}
+		next := slices.IndexFunc(queue, available)
+		if next < 0 {
+			round := slices.DeleteFunc(slices.Clone(team), func(name string) bool { return slices.Contains(queue, name) })
+			shuffle(round)
+			queue = append(queue, round...)
+			next = slices.IndexFunc(queue, available)
+		}
+		plan = append(plan, rotationDay{Date: day, Name: queue[next], Tentative: next >= confirmed})
+		queue = slices.Delete(queue, next, next+1)
+		if next < confirmed {
+			confirmed--
+		}
+	}
+	return plan
+}
+
+func runExportICS(cmd *cobra.Command, args []string) error {
+	weekdays, err := parseWeekdays(exportWeekdaysFlag)
+	if err != nil {
+		return err
+	}
+	if exportDaysFlag < 1 {
+		return fmt.Errorf("invalid --days %d: at least one day is needed", exportDaysFlag)
+	}
+	now := time.Now()
+	c := meetingCalendar{
+		start:    time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local),
+		days:     exportDaysFlag,
+		weekdays: weekdays,
+		away:     make(map[string][]dateRange),
+	}
+	if exportStartFlag != "" {
+		if c.start, err = time.ParseInLocation(dateLayout, exportStartFlag, time.Local); err != nil {
+			return fmt.Errorf("invalid --start '%s' (expected YYYY-MM-DD)", exportStartFlag)
+		}
+	}
+	for _, holiday := range exportHolidayFlags {
+		r, err := parseDateRange(holiday)
+		if err != nil {
+			return fmt.Errorf("invalid --holiday: %w", err)
+		}
+		c.holidays = append(c.holidays, r)
+	}
+
+	lang, err := selectLanguage(langFlag)
+	if err != nil {
+		return err
+	}
+	// Only the state is read: unlike a session, nothing is announced or
+	// notified, and no round is started
+	teamFile := getTeamFile(teamFileFlag)
+	team := loadTeamOrExit(teamFile, lang)
+	p := picker.New(team, getStateFile(), getHistoryFile())
+	for _, away := range exportAwayFlags {
+		i := strings.LastIndex(away, "=")
+		if i < 0 {
+			return fmt.Errorf("invalid --away '%s' (expected e.g. Bob=2026-10-21..2026-10-23)", away)
+		}
+		j := slices.IndexFunc(team, func(name string) bool { return strings.EqualFold(name, strings.TrimSpace(away[:i])) })
+		if j < 0 {
+			return fmt.Errorf("invalid --away '%s': no team member named '%s'", away, away[:i])
+		}
+		r, err := parseDateRange(away[i+1:])
+		if err != nil {
+			return fmt.Errorf("invalid --away '%s': %w", away, err)
+		}
+		c.away[team[j]] = append(c.away[team[j]], r)
+	}
+
+	remaining, err := p.Remaining()
+	if err != nil {
+		return err
+	}
+	// Guests only take part in the meeting they joined
+	remaining = slices.DeleteFunc(remaining, p.IsGuest)
+	plan := planRotation(remaining, team, c, func(names []string) {
+		rand.Shuffle(len(names), func(i, j int) { names[i], names[j] = names[j], names[i] })
+	})
+
+	w := io.Writer(os.Stdout)
+	if len(args) > 0 && args[0] != "-" {
+		f, err := os.Create(args[0])
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := f.Close(); err != nil {
+				fmt.Printf("Warning: failed to close file: %v\n", err)
+			}
+		}()
+		w = f
+	}
+	return writeICS(w, plan, icsCalendarID(teamFile), lang, now)
+}
+
+// Identifies the events of a team, so that importing a newer export updates
+// them rather than adding duplicates
+func icsCalendarID(teamFile string) string {
+	if teamFile == "-" {
+		return "stdin"
+	}
+	return strings.TrimSuffix(filepath.Base(teamFile), filepath.Ext(teamFile))
+}
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+// Write the plan as an iCalendar (RFC 5545) file of all-day events
+func writeICS(w io.Writer, plan []rotationDay, calendarID string, lang *language, now time.Time) error {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//daily-scrum-picker//Rotation//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:" + icsEscaper.Replace(lang.T("ics.calendar")),
+	}
+	for _, day := range plan {
+		status := "CONFIRMED"
+		if day.Tentative {
+			status = "TENTATIVE"
+		}
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			fmt.Sprintf("UID:%s-%s@daily-scrum-picker", day.Date.Format("20060102"), calendarID),
+			"DTSTAMP:"+now.UTC().Format("20060102T150405Z"),
+			"DTSTART;VALUE=DATE:"+day.Date.Format("20060102"),
+			"DTEND;VALUE=DATE:"+day.Date.AddDate(0, 0, 1).Format("20060102"),
+			"SUMMARY:"+icsEscaper.Replace(lang.T("ics.summary", day.Name)),
+			"STATUS:"+status,
+			// Do not show the day as busy
+			"TRANSP:TRANSPARENT",
+		)
+		if day.Tentative {
+			lines = append(lines, "DESCRIPTION:"+icsEscaper.Replace(lang.T("ics.tentative")))
+		}
+		lines = append(lines, "END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	for _, line := range lines {
+		if _, err := io.WriteString(w, foldICSLine(line)+"\r\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Fold a content line longer than 75 octets, without splitting characters
+func foldICSLine(line string) string {
+	var folded strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > 75 {
+			folded.WriteString("\r\n ")
+			width = 1
+		}
+		folded.WriteRune(r)
+		width += size
+	}
+	return folded.String()
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func day(t *testing.T, s string) time.Time {
+	t.Helper()
+	d, err := time.ParseInLocation(dateLayout, s, time.Local)
+	if err != nil {
+		t.Fatalf("Invalid date %s: %v", s, err)
+	}
+	return d
+}
+
+func mustDateRange(t *testing.T, s string) dateRange {
+	t.Helper()
+	r, err := parseDateRange(s)
+	if err != nil {
+		t.Fatalf("parseDateRange(%q) failed: %v", s, err)
+	}
+	return r
+}
+
+func TestPlanRotation(t *testing.T) {
+	// Monday 2026-10-19
+	c := meetingCalendar{
+		start:    day(t, "2026-10-19"),
+		days:     6,
+		weekdays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		holidays: []dateRange{mustDateRange(t, "2026-10-21")},
+		away:     map[string][]dateRange{"Bob": {mustDateRange(t, "2026-10-19..2026-10-20")}},
+	}
+	var shuffled [][]string
+	plan := planRotation([]string{"Bob", "Carol"}, []string{"Alice", "Bob", "Carol"}, c, func(names []string) {
+		shuffled = append(shuffled, slices.Clone(names))
+		slices.Reverse(names)
+	})
+
+	want := []rotationDay{
+		{Date: day(t, "2026-10-19"), Name: "Carol"},
+		// Bob is still away, so the next round starts without him
+		{Date: day(t, "2026-10-20"), Name: "Carol", Tentative: true},
+		// 2026-10-21 is a holiday; Bob kept his place in the current round
+		{Date: day(t, "2026-10-22"), Name: "Bob"},
+		{Date: day(t, "2026-10-23"), Name: "Alice", Tentative: true},
+		// Weekend
+		{Date: day(t, "2026-10-26"), Name: "Carol", Tentative: true},
+		{Date: day(t, "2026-10-27"), Name: "Bob", Tentative: true},
+	}
+	if !slices.Equal(plan, want) {
+		t.Errorf("Expected plan\n%v\ngot\n%v", want, plan)
+	}
+	if len(shuffled) != 2 {
+		t.Errorf("Expected two rounds to be shuffled, got %v", shuffled)
+	}
+}
+
+func TestPlanRotationEveryoneAway(t *testing.T) {
+	c := meetingCalendar{
+		start:    day(t, "2026-10-19"),
+		days:     1,
+		weekdays: []time.Weekday{time.Monday},
+		away:     map[string][]dateRange{"Alice": {mustDateRange(t, "2026-10-01..2026-10-31")}},
+	}
+	plan := planRotation([]string{"Alice"}, []string{"Alice"}, c, func([]string) {})
+	if len(plan) != 1 || !plan[0].Date.Equal(day(t, "2026-11-02")) || plan[0].Name != "Alice" {
+		t.Errorf("Expected Alice on the first Monday she is back, got %v", plan)
+	}
+}
+
+func TestParseDateRange(t *testing.T) {
+	for _, s := range []string{"", "2026-13-01", "2026-10-21..", "2026-10-23..2026-10-21"} {
+		if _, err := parseDateRange(s); err == nil {
+			t.Errorf("Expected an error for %q", s)
+		}
+	}
+	r := mustDateRange(t, "2026-10-21..2026-10-23")
+	if !r.contains(day(t, "2026-10-23")) || r.contains(day(t, "2026-10-24")) {
+		t.Errorf("Expected the range to include its last day only, got %v", r)
+	}
+}
+
+func TestParseWeekdays(t *testing.T) {
+	weekdays, err := parseWeekdays("Mon, wednesday,fri")
+	if err != nil {
+		t.Fatalf("parseWeekdays failed: %v", err)
+	}
+	if !slices.Equal(weekdays, []time.Weekday{time.Monday, time.Wednesday, time.Friday}) {
+		t.Errorf("Unexpected weekdays %v", weekdays)
+	}
+	for _, s := range []string{"", "mo", "mon,funday"} {
+		if _, err := parseWeekdays(s); err == nil {
+			t.Errorf("Expected an error for %q", s)
+		}
+	}
+}
+
+func TestWriteICS(t *testing.T) {
+	plan := []rotationDay{
+		{Date: day(t, "2026-10-19"), Name: "Alice"},
+		{Date: day(t, "2026-10-20"), Name: "Bob; Jr, the second of his name in this rather long team file", Tentative: true},
+	}
+	var buf bytes.Buffer
+	if err := writeICS(&buf, plan, "team", english, time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("writeICS failed: %v", err)
+	}
+	ics := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:20261019-team@daily-scrum-picker\r\n",
+		"DTSTAMP:20261019T083000Z\r\n",
+		"DTSTART;VALUE=DATE:20261019\r\nDTEND;VALUE=DATE:20261020\r\n",
+		"SUMMARY:Daily scrum facilitator: Alice\r\nSTATUS:CONFIRMED\r\n",
+		"SUMMARY:Daily scrum facilitator: Bob\\; Jr\\, the second of his name in this \r\n rather long team file\r\nSTATUS:TENTATIVE\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("Expected %q in\n%s", want, ics)
+		}
+	}
+	if strings.Count(ics, "BEGIN:VEVENT") != 2 || strings.Count(ics, "DESCRIPTION:") != 1 {
+		t.Errorf("Expected two events, one of them tentative, got\n%s", ics)
+	}
+	for _, line := range strings.Split(ics, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("Expected lines of at most 75 octets, got %q", line)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rm3l/daily-scrum-picker/picker"
+	"github.com/rm3l/daily-scrum-picker/pickerpb"
+)
+
+// Room used by gRPC requests that do not name one
+const defaultRoomID = "default"
+
+// gRPC front-end to the rooms of a hub, for bots that do not speak HTTP. It
+// goes through each room's server, so that calls are serialized with HTTP
+// requests and seen by live clients.
+type grpcServer struct {
+	pickerpb.UnimplementedPickerServer
+	hub *hub
+}
+
+func newGRPCServer(h *hub) *grpc.Server {
+	s := grpc.NewServer()
+	pickerpb.RegisterPickerServer(s, &grpcServer{hub: h})
+	return s
+}
+
+// Room of the request, if the caller has at least the needed role in it
+func (g *grpcServer) room(ctx context.Context, id string, need role) (*room, error) {
+	if id == "" {
+		id = defaultRoomID
+	}
+	rm, ok := g.hub.room(id)
+	if !ok {
+		return nil, grpcError(errNoSuchRoom)
+	}
+
+	got, valid := rm.access.roleOf(grpcToken(ctx))
+	switch {
+	case got >= need:
+		return rm, nil
+	case valid:
+		return nil, grpcError(errForbidden)
+	default:
+		return nil, grpcError(errUnauthorized)
+	}
+}
+
+// Token of a call, from an "authorization: Bearer" metadata entry
+func grpcToken(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, auth := range md.Get("authorization") {
+		if token, ok := strings.CutPrefix(auth, "Bearer "); ok {
+			return strings.TrimSpace(token)
+		}
+	}
+	return ""
+}
+
+func (g *grpcServer) Pick(ctx context.Context, req *pickerpb.PickRequest) (*pickerpb.PickResponse, error) {
+	rm, err := g.room(ctx, req.GetRoom(), roleFacilitator)
+	if err != nil {
+		return nil, err
+	}
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	result, err := rm.session.Pick(req.GetName())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pickerpb.PickResponse{
+		Name:      result.Name,
+		Action:    result.Action,
+		Remaining: result.Remaining,
+		NewRound:  result.NewRound,
+	}, nil
+}
+
+func (g *grpcServer) Skip(ctx context.Context, req *pickerpb.MemberRequest) (*pickerpb.MemberResponse, error) {
+	rm, err := g.room(ctx, req.GetRoom(), roleFacilitator)
+	if err != nil {
+		return nil, err
+	}
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	return memberResponse(rm.session.Skip(req.GetName()))
+}
+
+func (g *grpcServer) MarkAbsent(ctx context.Context, req *pickerpb.MemberRequest) (*pickerpb.MemberResponse, error) {
+	rm, err := g.room(ctx, req.GetRoom(), roleFacilitator)
+	if err != nil {
+		return nil, err
+	}
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	return memberResponse(rm.session.MarkAbsent(req.GetName()))
+}
+
+func (g *grpcServer) Undo(ctx context.Context, req *pickerpb.RoomRequest) (*pickerpb.MemberResponse, error) {
+	rm, err := g.room(ctx, req.GetRoom(), roleFacilitator)
+	if err != nil {
+		return nil, err
+	}
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	return memberResponse(rm.session.Undo())
+}
+
+func (g *grpcServer) Reset(ctx context.Context, req *pickerpb.RoomRequest) (*pickerpb.StatusResponse, error) {
+	rm, err := g.room(ctx, req.GetRoom(), roleFacilitator)
+	if err != nil {
+		return nil, err
+	}
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	if err := rm.session.Reset(); err != nil {
+		return nil, grpcError(err)
+	}
+	return statusResponse(rm.session.Status())
+}
+
+func (g *grpcServer) GetStatus(ctx context.Context, req *pickerpb.RoomRequest) (*pickerpb.StatusResponse, error) {
+	rm, err := g.room(ctx, req.GetRoom(), roleViewer)
+	if err != nil {
+		return nil, err
+	}
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	return statusResponse(rm.session.Status())
+}
+
+// Relay the changes sent to live clients of the room. As with them, a client
+// too slow to keep up is disconnected.
+func (g *grpcServer) WatchEvents(req *pickerpb.RoomRequest, stream grpc.ServerStreamingServer[pickerpb.Event]) error {
+	rm, err := g.room(stream.Context(), req.GetRoom(), roleViewer)
+	if err != nil {
+		return err
+	}
+	ch := rm.events.subscribe()
+	defer rm.events.unsubscribe(ch)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case msg, ok := <-ch:
+			if !ok {
+				return status.Error(codes.Unavailable, "event stream closed")
+			}
+			if msg.event == "tick" {
+				continue
+			}
+			var update liveUpdate
+			if err := json.Unmarshal(msg.data, &update); err != nil {
+				return grpcError(err)
+			}
+			if err := stream.Send(eventMessage(update.Event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func memberResponse(result picker.MemberResult, err error) (*pickerpb.MemberResponse, error) {
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pickerpb.MemberResponse{Name: result.Name, Action: result.Action, Remaining: result.Remaining}, nil
+}
+
+func statusResponse(report picker.Status, err error) (*pickerpb.StatusResponse, error) {
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pickerpb.StatusResponse{
+		TeamMembers: int32(report.TeamMembers),
+		Remaining:   report.Remaining,
+		Guests:      report.Guests,
+	}, nil
+}
+
+func eventMessage(event picker.Event) *pickerpb.Event {
+	return &pickerpb.Event{
+		Type:      event.Type,
+		Time:      timestamppb.New(event.Time),
+		Name:      event.Name,
+		Action:    event.Action,
+		Remaining: event.Remaining,
+		NewRound:  event.NewRound,
+	}
+}
+
+// Map picker errors to gRPC status codes, as errorStatus does for HTTP
+func grpcError(err error) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, picker.ErrNoName), errors.Is(err, errInvalidRoomID), errors.Is(err, errNoMembersGiven):
+		code = codes.InvalidArgument
+	case errors.Is(err, errUnauthorized):
+		code = codes.Unauthenticated
+	case errors.Is(err, errForbidden):
+		code = codes.PermissionDenied
+	case errors.Is(err, picker.ErrNoSuchMember), errors.Is(err, errNoSuchRoom):
+		code = codes.NotFound
+	case errors.Is(err, picker.ErrAmbiguousMember), errors.Is(err, picker.ErrNothingToUndo), errors.Is(err, picker.ErrNoMembers):
+		code = codes.FailedPrecondition
+	case errors.Is(err, errRoomExists), errors.Is(err, errRoomIDClash):
+		code = codes.AlreadyExists
+	}
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/rm3l/daily-scrum-picker/picker"
+	"github.com/rm3l/daily-scrum-picker/pickerpb"
+)
+
+func newGRPCTestClient(t *testing.T, h *hub) pickerpb.PickerClient {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	s := newGRPCServer(h)
+	go func() {
+		_ = s.Serve(listener)
+	}()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := conn.Close(); err != nil {
+			t.Logf("Warning: failed to close connection: %v", err)
+		}
+	})
+	return pickerpb.NewPickerClient(conn)
+}
+
+func TestGRPC_Commands(t *testing.T) {
+	h, _ := newTestHub(t, map[string]string{"payments.txt": "Alice\nBob\nCharlie\n"})
+	client := newGRPCTestClient(t, h)
+	ctx := t.Context()
+
+	pick, err := client.Pick(ctx, &pickerpb.PickRequest{Room: "payments", Name: "bob"})
+	if err != nil {
+		t.Fatalf("Pick failed: %v", err)
+	}
+	if pick.GetName() != "Bob" || pick.GetAction() != picker.ActionManualPick || len(pick.GetRemaining()) != 2 {
+		t.Errorf("Unexpected pick: %v", pick)
+	}
+
+	absent, err := client.MarkAbsent(ctx, &pickerpb.MemberRequest{Room: "payments", Name: "Charlie"})
+	if err != nil {
+		t.Fatalf("MarkAbsent failed: %v", err)
+	}
+	if absent.GetName() != "Charlie" || absent.GetAction() != picker.ActionAbsent {
+		t.Errorf("Unexpected absence: %v", absent)
+	}
+
+	skip, err := client.Skip(ctx, &pickerpb.MemberRequest{Room: "payments"})
+	if err != nil {
+		t.Fatalf("Skip failed: %v", err)
+	}
+	if skip.GetName() != "Alice" || skip.GetAction() != picker.ActionSkip {
+		t.Errorf("Unexpected skip: %v", skip)
+	}
+
+	undo, err := client.Undo(ctx, &pickerpb.RoomRequest{Room: "payments"})
+	if err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if undo.GetName() != "Alice" {
+		t.Errorf("Expected the skip to be undone, got %v", undo)
+	}
+
+	st, err := client.GetStatus(ctx, &pickerpb.RoomRequest{Room: "payments"})
+	if err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+	if st.GetTeamMembers() != 3 || len(st.GetRemaining()) != 1 {
+		t.Errorf("Unexpected status: %v", st)
+	}
+
+	st, err = client.Reset(ctx, &pickerpb.RoomRequest{Room: "payments"})
+	if err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if len(st.GetRemaining()) != 3 {
+		t.Errorf("Expected everyone to be remaining after a reset, got %v", st)
+	}
+}
+
+func TestGRPC_Errors(t *testing.T) {
+	h, _ := newTestHub(t, map[string]string{"payments.txt": "Alice\nAlicia\n"})
+	client := newGRPCTestClient(t, h)
+	ctx := t.Context()
+
+	tests := []struct {
+		name     string
+		call     func() error
+		expected codes.Code
+	}{
+		{
+			name: "unknown room",
+			call: func() error {
+				_, err := client.GetStatus(ctx, &pickerpb.RoomRequest{Room: "unknown"})
+				return err
+			},
+			expected: codes.NotFound,
+		},
+		{
+			name: "no default room",
+			call: func() error {
+				_, err := client.GetStatus(ctx, &pickerpb.RoomRequest{})
+				return err
+			},
+			expected: codes.NotFound,
+		},
+		{
+			name: "unknown member",
+			call: func() error {
+				_, err := client.Pick(ctx, &pickerpb.PickRequest{Room: "payments", Name: "Zoe"})
+				return err
+			},
+			expected: codes.NotFound,
+		},
+		{
+			name: "ambiguous member",
+			call: func() error {
+				_, err := client.Pick(ctx, &pickerpb.PickRequest{Room: "payments", Name: "Ali"})
+				return err
+			},
+			expected: codes.FailedPrecondition,
+		},
+		{
+			name: "absent without a name",
+			call: func() error {
+				_, err := client.MarkAbsent(ctx, &pickerpb.MemberRequest{Room: "payments"})
+				return err
+			},
+			expected: codes.InvalidArgument,
+		},
+		{
+			name: "nothing to undo",
+			call: func() error {
+				_, err := client.Undo(ctx, &pickerpb.RoomRequest{Room: "payments"})
+				return err
+			},
+			expected: codes.FailedPrecondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(tt.call()); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGRPC_AccessTokens(t *testing.T) {
+	h, _ := newTestHub(t, map[string]string{"payments.txt": "Alice\nBob\n"})
+	rm, _ := h.room("payments")
+	rm.access = access{facilitator: "lead", viewer: "team"}
+	client := newGRPCTestClient(t, h)
+
+	withToken := func(token string) context.Context {
+		return metadata.AppendToOutgoingContext(t.Context(), "authorization", "Bearer "+token)
+	}
+
+	if _, err := client.GetStatus(t.Context(), &pickerpb.RoomRequest{Room: "payments"}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated without a token, got %v", err)
+	}
+	if _, err := client.GetStatus(withToken("team"), &pickerpb.RoomRequest{Room: "payments"}); err != nil {
+		t.Errorf("Expected viewers to get the status, got %v", err)
+	}
+	if _, err := client.Pick(withToken("team"), &pickerpb.PickRequest{Room: "payments"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for a viewer pick, got %v", err)
+	}
+	if _, err := client.Pick(withToken("lead"), &pickerpb.PickRequest{Room: "payments"}); err != nil {
+		t.Errorf("Expected facilitators to pick, got %v", err)
+	}
+}
+
+func TestGRPC_WatchEvents(t *testing.T) {
+	h, _ := newTestHub(t, map[string]string{"payments.txt": "Alice\nBob\n"})
+	client := newGRPCTestClient(t, h)
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.WatchEvents(ctx, &pickerpb.RoomRequest{Room: "payments"})
+	if err != nil {
+		t.Fatalf("WatchEvents failed: %v", err)
+	}
+	// Wait for the subscription before changing the round
+	rm, _ := h.room("payments")
+	for deadline := time.Now().Add(2 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		rm.events.mu.Lock()
+		subscribed := len(rm.events.clients) > 0
+		rm.events.mu.Unlock()
+		if subscribed {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Stream did not subscribe to events")
+		}
+	}
+
+	if _, err := client.Pick(ctx, &pickerpb.PickRequest{Room: "payments", Name: "Bob"}); err != nil {
+		t.Fatalf("Pick failed: %v", err)
+	}
+	if _, err := client.Pick(ctx, &pickerpb.PickRequest{Room: "payments"}); err != nil {
+		t.Fatalf("Pick failed: %v", err)
+	}
+
+	for _, expected := range []struct{ eventType, name string }{
+		{picker.EventPick, "Bob"},
+		{picker.EventPick, "Alice"},
+		{picker.EventRoundComplete, ""},
+	} {
+		event, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Failed to receive event: %v", err)
+		}
+		if event.GetType() != expected.eventType || event.GetName() != expected.name {
+			t.Errorf("Expected %s event for %q, got %v", expected.eventType, expected.name, event)
+		}
+		if event.GetTime().AsTime().IsZero() {
+			t.Errorf("Expected the event time to be set, got %v", event)
+		}
+	}
+}
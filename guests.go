@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/rm3l/daily-scrum-picker/picker"
+)
+
+// Guest who cannot join the round, e.g. because they already are in it
+type guestError struct {
+	name string
+	err  error
+}
+
+func (e *guestError) Error() string {
+	return fmt.Sprintf("%s is %v", e.name, e.err)
+}
+
+func (e *guestError) Unwrap() error {
+	return e.err
+}
+
+// Add a temporary participant to the current round. The team file is left
+// untouched and the guest is dropped once the round or the meeting ends.
+func addGuest(s *session, name string) {
+	name = strings.TrimSpace(name)
+	err := s.AddGuest(name)
+	switch {
+	case errors.Is(err, picker.ErrGuestIsMember), errors.Is(err, picker.ErrAlreadyInRound):
+		s.render.Error("add guest", &guestError{name: name, err: err})
+	case err != nil:
+		s.render.Error("add guest", err)
+	default:
+		s.render.GuestAdded(name)
+	}
+}
+
+// Ask for a guest name on a raw terminal
+func promptGuestRaw(s *session) {
+	oldState, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		s.tell("error.rawMode", err)
+		return
+	}
+	name, err := readLineRaw(os.Stdin, s.console, s.lang.T("prompt.guest"), nil)
+	if err := term.Restore(int(os.Stdin.Fd()), oldState); err != nil {
+		s.tell("error.restoreTerminal", err)
+	}
+
+	if errors.Is(err, errPromptCancelled) {
+		s.tell("guestCancelled")
+		return
+	}
+	if err != nil {
+		s.tell("error.readInput", err)
+		return
+	}
+	addGuest(s, name)
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAddGuest_RejectsDuplicatesAndEmptyNames(t *testing.T) {
+	s := newTestSession(t, "Alice", "Bob")
+	setTestRound(t, s, "Alice", "Bob")
+
+	addGuest(s, "")
+	addGuest(s, "alice")
+	addGuest(s, "  Carol ")
+	addGuest(s, "carol")
+
+	remaining := testRemaining(t, s)
+	if len(remaining) != 3 || !slices.Contains(remaining, "Carol") {
+		t.Errorf("Expected only Carol to be added once, got %v", remaining)
+	}
+	if !slices.Equal(s.Guests(), []string{"Carol"}) {
+		t.Errorf("Expected Carol to be the only guest, got %v", s.Guests())
+	}
+}
+
+func TestGuestsDoNotCarryOverToNextRound(t *testing.T) {
+	s := newTestSession(t, "Alice")
+	setTestRound(t, s, "Alice")
+	addGuest(s, "Carol")
+
+	pickNextPerson(s)
+	pickNextPerson(s)
+	if len(s.Guests()) != 0 {
+		t.Errorf("Expected guests to be cleared once the round ends, got %v", s.Guests())
+	}
+
+	// The next round only contains team members
+	if remaining, _, _ := s.CurrentRound(); !slices.Equal(remaining, []string{"Alice"}) {
+		t.Errorf("Expected next round to only contain team members, got %v", remaining)
+	}
+}
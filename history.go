@@ -0,0 +1,14 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+)
+
+func getHistoryFile() string {
+	if historyFile := os.Getenv("HISTORY_FILE"); historyFile != "" {
+		return historyFile
+	}
+	// Keep the history next to the default state file
+	return filepath.Join(os.TempDir(), "daily-scrum-picker-history.txt")
+}
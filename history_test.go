@@ -0,0 +1,18 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestGetHistoryFile(t *testing.T) {
+	t.Setenv("HISTORY_FILE", "/custom/path/history.txt")
+	if result := getHistoryFile(); result != "/custom/path/history.txt" {
+		t.Errorf("getHistoryFile() = %q; want %q", result, "/custom/path/history.txt")
+	}
+
+	t.Setenv("HISTORY_FILE", "")
+	if result := getHistoryFile(); filepath.Base(result) != "daily-scrum-picker-history.txt" {
+		t.Errorf("Default history file should be 'daily-scrum-picker-history.txt', got %q", result)
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rm3l/daily-scrum-picker/picker"
+)
+
+const (
+	defaultHookTimeout = 10 * time.Second
+	// Output of a failed command included in its error, at most
+	maxHookOutput = 512
+)
+
+// Events a hook runs on when none are configured
+var defaultHookEvents = []string{picker.EventPick}
+
+// Local command to run on events, as set in the config file, e.g.
+//
+//	{"command": "say \"$SCRUM_NAME\"", "events": ["pick"]}
+type hookConfig struct {
+	// Run by the shell (sh, or cmd on Windows)
+	Command string `json:"command"`
+	// Types of events to run on, e.g. ["pick", "roundComplete"]
+	Events  []string `json:"events,omitempty"`
+	Timeout duration `json:"timeout,omitempty"`
+}
+
+func (c hookConfig) validate() error {
+	if strings.TrimSpace(c.Command) == "" {
+		return errors.New("command cannot be empty")
+	}
+	return validateEventTypes(c.Events)
+}
+
+// Runs a command for each event in the background, one at a time, so that a
+// slow command never holds up the interactive loop
+type hook struct {
+	config  hookConfig
+	room    string
+	timeout time.Duration
+	queue   chan picker.Event
+	done    chan struct{}
+}
+
+func newHook(config hookConfig, room string) *hook {
+	timeout := time.Duration(config.Timeout)
+	if timeout <= 0 {
+		timeout = defaultHookTimeout
+	}
+	if len(config.Events) == 0 {
+		config.Events = defaultHookEvents
+	}
+
+	h := &hook{
+		config:  config,
+		room:    room,
+		timeout: timeout,
+		queue:   make(chan picker.Event, 64),
+		done:    make(chan struct{}),
+	}
+	go h.run()
+	return h
+}
+
+// Run the hooks configured in cfg on events of the session, until the session
+// is closed
+func (s *session) runHooks(cfg config, room string) {
+	if len(cfg.Hooks) == 0 {
+		return
+	}
+	hooks := make([]*hook, len(cfg.Hooks))
+	for i, hookCfg := range cfg.Hooks {
+		hooks[i] = newHook(hookCfg, room)
+	}
+
+	s.Subscribe(func(event picker.Event) {
+		for _, h := range hooks {
+			h.enqueue(event)
+		}
+	})
+	s.onClose(func() {
+		deadline := time.After(defaultHookTimeout)
+		for _, h := range hooks {
+			h.close()
+		}
+		for _, h := range hooks {
+			select {
+			case <-h.done:
+			case <-deadline:
+				log.Printf("Warning: gave up waiting for hook '%s'", h.config.Command)
+				return
+			}
+		}
+	})
+}
+
+func (h *hook) enqueue(event picker.Event) {
+	if !slices.Contains(h.config.Events, event.Type) {
+		return
+	}
+	select {
+	case h.queue <- event:
+	default:
+		log.Printf("Warning: too many pending events for hook '%s', dropping %s event", h.config.Command, event.Type)
+	}
+}
+
+// Stop accepting events; done is closed once the pending ones are handled
+func (h *hook) close() {
+	close(h.queue)
+}
+
+func (h *hook) run() {
+	defer close(h.done)
+	for event := range h.queue {
+		if err := h.exec(event); err != nil {
+			log.Printf("Warning: hook '%s' failed for %s event: %v", h.config.Command, event.Type, err)
+		}
+	}
+}
+
+// Run the command with the event in SCRUM_* environment variables, and as
+// JSON on its standard input
+func (h *hook) exec(event picker.Event) error {
+	input, err := json.Marshal(webhookEvent{Event: event, Room: h.room})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+
+	cmd := shellCommand(ctx, h.config.Command)
+	cmd.Env = append(os.Environ(), hookEnv(event, h.room)...)
+	cmd.Stdin = bytes.NewReader(input)
+	err = runCommand(cmd)
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("timed out after %s", h.timeout)
+	}
+	return err
+}
+
+// Run the command, with its output in the error if it fails
+func runCommand(cmd *exec.Cmd) error {
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	// Do not wait for background processes started by the command
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Run(); err != nil {
+		if out := strings.TrimSpace(output.String()); out != "" {
+			if len(out) > maxHookOutput {
+				out = out[:maxHookOutput] + "..."
+			}
+			return fmt.Errorf("%w: %s", err, out)
+		}
+		return err
+	}
+	return nil
+}
+
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
+
+// Environment variables describing the event to a hook command
+func hookEnv(event picker.Event, room string) []string {
+	return []string{
+		"SCRUM_EVENT=" + event.Type,
+		"SCRUM_TIME=" + event.Time.Format(time.RFC3339),
+		"SCRUM_NAME=" + event.Name,
+		"SCRUM_ACTION=" + event.Action,
+		"SCRUM_REMAINING=" + strings.Join(event.Remaining, ","),
+		"SCRUM_ROOM=" + room,
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rm3l/daily-scrum-picker/picker"
+)
+
+func skipWithoutShell(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("hook tests use sh")
+	}
+}
+
+func TestHooksRunOnEvents(t *testing.T) {
+	skipWithoutShell(t)
+	out := filepath.Join(t.TempDir(), "out.txt")
+	s := newTestSession(t, "Alice", "Bob")
+	setTestRound(t, s, "Alice", "Bob")
+	s.runHooks(config{Hooks: []hookConfig{{
+		Command: `echo "$SCRUM_EVENT $SCRUM_NAME [$SCRUM_REMAINING] $SCRUM_ROOM" >> ` + out,
+		Events:  []string{picker.EventPick, picker.EventRoundComplete},
+	}}}, "payments")
+
+	if _, err := s.Pick("Alice"); err != nil {
+		t.Fatalf("Pick failed: %v", err)
+	}
+	// Skips are not configured
+	if _, err := s.Skip(""); err != nil {
+		t.Fatalf("Skip failed: %v", err)
+	}
+	if _, err := s.Pick(""); err != nil {
+		t.Fatalf("Pick failed: %v", err)
+	}
+	s.close()
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("Hook did not run: %v", err)
+	}
+	expected := "pick Alice [Bob] payments\npick Bob [] payments\nroundComplete  [] payments\n"
+	if string(data) != expected {
+		t.Errorf("Expected hook output %q, got %q", expected, string(data))
+	}
+}
+
+func TestHookReceivesEventOnStdin(t *testing.T) {
+	skipWithoutShell(t)
+	out := filepath.Join(t.TempDir(), "event.json")
+	h := &hook{config: hookConfig{Command: "cat > " + out}, timeout: time.Second}
+
+	if err := h.exec(picker.Event{Type: picker.EventPick, Name: "Alice", Remaining: []string{"Bob"}}); err != nil {
+		t.Fatalf("Hook failed: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("Failed to read hook input: %v", err)
+	}
+	var event webhookEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		t.Fatalf("Invalid JSON on stdin: %v", err)
+	}
+	if event.Type != picker.EventPick || event.Name != "Alice" || len(event.Remaining) != 1 {
+		t.Errorf("Unexpected event: %+v", event)
+	}
+}
+
+func TestHookErrors(t *testing.T) {
+	skipWithoutShell(t)
+	tests := []struct {
+		name     string
+		command  string
+		timeout  time.Duration
+		contains string
+	}{
+		{name: "failure with output", command: "echo 'speaker not found' >&2; exit 3", timeout: time.Second, contains: "exit status 3: speaker not found"},
+		{name: "timeout", command: "sleep 5", timeout: 50 * time.Millisecond, contains: "timed out"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &hook{config: hookConfig{Command: tt.command}, timeout: tt.timeout}
+			start := time.Now()
+			err := h.exec(picker.Event{Type: picker.EventPick, Name: "Alice"})
+			if err == nil || !strings.Contains(err.Error(), tt.contains) {
+				t.Errorf("Expected error containing %q, got %v", tt.contains, err)
+			}
+			if elapsed := time.Since(start); elapsed > 3*time.Second {
+				t.Errorf("Hook was not stopped in time (%s)", elapsed)
+			}
+		})
+	}
+}
+
+func TestHookDoesNotBlockEvents(t *testing.T) {
+	skipWithoutShell(t)
+	s := newTestSession(t, "Alice", "Bob")
+	setTestRound(t, s, "Alice", "Bob")
+	s.runHooks(config{Hooks: []hookConfig{{Command: "sleep 1", Timeout: duration(2 * time.Second)}}}, "")
+	defer s.close()
+
+	start := time.Now()
+	if _, err := s.Pick(""); err != nil {
+		t.Fatalf("Pick failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Pick waited for the hook (%s)", elapsed)
+	}
+}
+
+func TestHookConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  hookConfig
+		wantErr bool
+	}{
+		{name: "valid", config: hookConfig{Command: "say hi", Events: []string{picker.EventPick, picker.EventMeetingEnd}}},
+		{name: "default events", config: hookConfig{Command: "say hi"}},
+		{name: "empty command", config: hookConfig{Command: "  "}, wantErr: true},
+		{name: "unknown event", config: hookConfig{Command: "say hi", Events: []string{"lunch"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Expected error: %v, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/rm3l/daily-scrum-picker/picker"
+)
+
+// Catalog of the user-facing strings of a language, as fmt formats by id.
+// Strings depending on a count have a form per plural category, with ".one"
+// and ".other" suffixes.
+type language struct {
+	code    string
+	strings map[string]string
+	// Whether a count takes the ".one" form
+	one func(n int) bool
+}
+
+// Supported languages, by ISO 639-1 code
+var languages = map[string]*language{
+	english.code:    english,
+	french.code:     french,
+	portuguese.code: portuguese,
+}
+
+func languageCodes() []string {
+	return slices.Sorted(maps.Keys(languages))
+}
+
+// Language of the messages: the --lang flag, or else the locale of the
+// environment (LC_ALL, LC_MESSAGES, then LANG, e.g. fr_FR.UTF-8), falling
+// back to English
+func selectLanguage(flag string) (*language, error) {
+	if flag != "" {
+		l, ok := languages[languageCode(flag)]
+		if !ok {
+			return nil, fmt.Errorf("unknown language '%s' (expected one of %s)", flag, strings.Join(languageCodes(), ", "))
+		}
+		return l, nil
+	}
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if locale := os.Getenv(key); locale != "" {
+			if l, ok := languages[languageCode(locale)]; ok {
+				return l, nil
+			}
+			// The first locale set wins, even if it is not supported (e.g. C)
+			return english, nil
+		}
+	}
+	return english, nil
+}
+
+// Language code of a locale, e.g. pt for pt_BR.UTF-8 or pt-BR
+func languageCode(locale string) string {
+	code, _, _ := strings.Cut(locale, ".")
+	code, _, _ = strings.Cut(code, "@")
+	code, _, _ = strings.Cut(code, "_")
+	code, _, _ = strings.Cut(code, "-")
+	return strings.ToLower(code)
+}
+
+// Format the string with the given id. If the first argument is an int and
+// the string has plural forms, the form matching that count is used. Strings
+// missing from the catalog fall back to English.
+func (l *language) T(id string, args ...any) string {
+	if len(args) > 0 {
+		if n, ok := args[0].(int); ok {
+			form := id + ".other"
+			if l.one(n) {
+				form = id + ".one"
+			}
+			if l.has(form) {
+				id = form
+			}
+		}
+	}
+	return fmt.Sprintf(l.lookup(id), args...)
+}
+
+// Pick the form of text matching the count n, formatted with n
+func (l *language) plural(n int, one, other string) string {
+	if l.one(n) {
+		return fmt.Sprintf(one, n)
+	}
+	return fmt.Sprintf(other, n)
+}
+
+func (l *language) has(id string) bool {
+	_, ok := l.strings[id]
+	_, inEnglish := english.strings[id]
+	return ok || inEnglish
+}
+
+func (l *language) lookup(id string) string {
+	if s, ok := l.strings[id]; ok {
+		return s
+	}
+	if s, ok := english.strings[id]; ok {
+		return s
+	}
+	return id
+}
+
+// Explain why an operation failed, e.g. action "pick". Errors of the picker
+// are translated; others (e.g. I/O errors) are shown as is.
+func (l *language) cannot(action string, err error) string {
+	id := "cannot " + action
+	if !l.has(id) {
+		return l.T("cannot", action, l.errorText(err))
+	}
+	return l.T(id, l.errorText(err))
+}
+
+func (l *language) errorText(err error) string {
+	var memberErr *picker.MemberError
+	var guestErr *guestError
+	switch {
+	case errors.As(err, &memberErr) && errors.Is(err, picker.ErrAmbiguousMember):
+		return l.T("error.ambiguous", memberErr.Input, strings.Join(memberErr.Matches, ", "))
+	case errors.As(err, &memberErr) && memberErr.OutOfRange:
+		return l.T("error.noSuchNumber", memberErr.Number, memberErr.Remaining)
+	case errors.As(err, &memberErr):
+		return l.T("error.noMatch", memberErr.Input)
+	case errors.As(err, &guestErr) && errors.Is(err, picker.ErrGuestIsMember):
+		return l.T("error.guestIsMember", guestErr.name)
+	case errors.As(err, &guestErr) && errors.Is(err, picker.ErrAlreadyInRound):
+		return l.T("error.alreadyInRound", guestErr.name)
+	case errors.Is(err, picker.ErrNoName):
+		return l.T("error.noName")
+	case errors.Is(err, picker.ErrNothingToUndo):
+		return l.T("error.nothingToUndo")
+	default:
+		return err.Error()
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+var english = &language{
+	code: "en",
+	one: func(n int) bool {
+		return n == 1
+	},
+	strings: map[string]string{
+		// Interactive mode
+		"welcome.teamFile":      "Team file: %s",
+		"welcome.stdin":         "Team source: stdin",
+		"welcome.stateFile":     "State file: %s",
+		"welcome.historyFile":   "History file: %s",
+		"members.one":           "%d member",
+		"members.other":         "%d members",
+		"commands":              "Commands:",
+		"pressAnyKey":           "Press any key (no Enter needed):",
+		"typeCommands":          "Type commands and press Enter:",
+		"unknownKey":            "Unknown command: '%s'. Press 'h' for help.",
+		"unknownCommand":        "Unknown command: '%s'. Type 'h' for help.",
+		"invalidCommand":        "Invalid command: %v",
+		"goodbye":               "Goodbye!",
+		"prompt.manualPick":     "Who goes next? (name or number): ",
+		"prompt.guest":          "Guest name: ",
+		"prompt.hint":           "(Tab completes names, Esc cancels)",
+		"manualPickCancelled":   "Manual pick cancelled.",
+		"guestCancelled":        "Adding guest cancelled.",
+		"fallbackBuffered":      "Falling back to buffered mode...",
+		"error":                 "Error: %v",
+		"error.rawMode":         "Error entering raw mode: %v",
+		"error.reenterRawMode":  "Error re-entering raw mode, exiting...",
+		"error.restoreTerminal": "Error restoring terminal: %v",
+		"error.readInput":       "Error reading input: %v",
+		"error.endMeeting":      "Warning: failed to end the meeting: %v",
+		"error.loadTeam":        "Error loading team members: %v",
+		"error.emptyTeamFile":   "No team members found in '%s'. Please add team member names (one per line).",
+		"error.emptyStdin":      "No team members found in stdin. Please provide team member names (one per line).",
+		"hint.teamFile":         "Please create a '%s' file with one team member name per line.",
+		"hint.stdin":            "Please provide team member names via stdin (one per line).",
+
+		// Outcome of operations
+		"newRound":        "Everyone has already had a turn. Resetting list...",
+		"pick":            "Next is... %s",
+		"manualPick":      "(manual pick)",
+		"remaining.one":   "%d person remaining in this round",
+		"remaining.other": "%d people remaining in this round",
+		"lastPerson":      "That was the last person in this round",
+		"onDeck":          "On deck: %s",
+		"skipped":         "Skipped %s, moved to the end of this round.",
+		"absent":          "%s is marked absent for this round.",
+		"undid.pick":      "Undid the pick of %s, back at the front of the round.",
+		"undid.skip":      "Undid the skip of %s, back at the front of the round.",
+		"undid.absence":   "Undid the absence of %s, back at the front of the round.",
+		"guestAdded":      "Welcome, %s! Added as a guest to this round.",
+		"reset.one":       "State reset! %d team member is available for selection.",
+		"reset.other":     "State reset! All %d team members are available for selection.",
+		"announce":        "%s, you're up!",
+		"a11y.pick":       "Next speaker: %s.",
+		"a11y.manualPick": "Next speaker, picked manually: %s.",
+		"guest":           "%s (guest)",
+
+		// Calendar export
+		"ics.calendar":  "Daily scrum facilitators",
+		"ics.summary":   "Daily scrum facilitator: %s",
+		"ics.tentative": "Tentative: this round is shuffled when it starts.",
+
+		// Chat messages (Slack, webhooks)
+		"chat.newRound":        "Everyone had a turn, so a new round started.",
+		"chat.remaining.one":   "%d remaining, on deck: %s",
+		"chat.remaining.other": "%d remaining, on deck: %s",
+		"chat.lastPerson":      "that was the last person in this round",
+		"chat.skipped":         "Skipped %s, moved to the end of the round",
+		"chat.absent":          "%s is away today",
+		"chat.undid.pick":      "Undid the pick of %s, back at the front of the round",
+		"chat.undid.skip":      "Undid the skip of %s, back at the front of the round",
+		"chat.undid.absence":   "Undid the absence of %s, back at the front of the round",
+		"chat.reset":           "The round was reset, everyone gets a turn again",
+		"chat.roundComplete":   "Everyone had a turn, the round is complete",
+		"chat.meetingEnd":      "The stand-up is over",
+		"chat.status":          "Remaining in this round (%d of %d):",
+		"chat.help":            "Usage: `/standup next [name]`, `skip [name]`, `absent <name>`, `undo`, `status` or `reset`",
+		"chat.unknownCommand":  "Unknown command: '%s'. %s",
+
+		// Status
+		"status.heading":        "Status:",
+		"status.title":          "Status",
+		"status.total":          "Total team members: %v",
+		"status.remaining":      "Remaining this round: %v",
+		"status.stillToPick":    "Still to pick:",
+		"status.everyonePicked": "Everyone has been picked this round",
+
+		// Help
+		"help.heading":     "Available commands:",
+		"help.title":       "Available commands",
+		"help.typing":      "When typing commands (Enter mode):",
+		"help.key":         "Key",
+		"help.command":     "Command",
+		"help.description": "Description",
+		"help.pick":        "Pick the next person for daily scrum",
+		"help.manual":      "Choose who goes next (e.g. someone who has to leave early)",
+		"help.guest":       "Add a guest to this round only (the team file is not changed)",
+		"help.undo":        "Undo the last pick, skip or absence",
+		"help.reset":       "Reset state and start over with all team members",
+		"help.status":      "Show current status and remaining team members",
+		"help.help":        "Show this help message",
+		"help.quit":        "Exit the program",
+		"help.pickName":    "Pick a specific remaining member",
+		"help.skipName":    "Move someone (next in line by default) to the end of the round",
+		"help.absentName":  "Remove an absent member from this round",
+		"help.addGuest":    "Add a guest to this round only",
+		"help.statusJSON":  "Show the status as JSON",
+
+		// Errors, e.g. "Cannot pick: no name given"
+		"cannot":                "Cannot %s: %s",
+		"cannot pick":           "Cannot pick: %s",
+		"cannot skip":           "Cannot skip: %s",
+		"cannot mark absent":    "Cannot mark absent: %s",
+		"cannot undo":           "Cannot undo: %s",
+		"cannot reset":          "Cannot reset: %s",
+		"cannot show status":    "Cannot show status: %s",
+		"cannot load the round": "Cannot load the round: %s",
+		"cannot add guest":      "Cannot add guest: %s",
+		"error.noName":          "no name given",
+		"error.nothingToUndo":   "nothing to undo",
+		"error.noMatch":         "nobody remaining matches '%s'",
+		"error.noSuchNumber":    "no remaining member with number %d (expected 1-%d)",
+		"error.ambiguous":       "'%s' could be %s",
+		"error.guestIsMember":   "%s is already a team member",
+		"error.alreadyInRound":  "%s is already in this round",
+	},
+}
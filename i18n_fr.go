@@ -0,0 +1,132 @@
+package main
+
+var french = &language{
+	code: "fr",
+	// 0 and 1 are singular in French
+	one: func(n int) bool {
+		return n == 0 || n == 1
+	},
+	strings: map[string]string{
+		// Interactive mode
+		"welcome.teamFile":      "Fichier d'équipe : %s",
+		"welcome.stdin":         "Équipe : entrée standard",
+		"welcome.stateFile":     "Fichier d'état : %s",
+		"welcome.historyFile":   "Fichier d'historique : %s",
+		"members.one":           "%d membre",
+		"members.other":         "%d membres",
+		"commands":              "Commandes :",
+		"pressAnyKey":           "Appuyez sur une touche (sans Entrée) :",
+		"typeCommands":          "Tapez une commande puis Entrée :",
+		"unknownKey":            "Commande inconnue : '%s'. Appuyez sur 'h' pour l'aide.",
+		"unknownCommand":        "Commande inconnue : '%s'. Tapez 'h' pour l'aide.",
+		"invalidCommand":        "Commande invalide : %v",
+		"goodbye":               "Au revoir !",
+		"prompt.manualPick":     "Qui passe ensuite ? (nom ou numéro) : ",
+		"prompt.guest":          "Nom de l'invité : ",
+		"prompt.hint":           "(Tab complète les noms, Échap annule)",
+		"manualPickCancelled":   "Choix manuel annulé.",
+		"guestCancelled":        "Ajout de l'invité annulé.",
+		"fallbackBuffered":      "Passage en mode ligne par ligne...",
+		"error":                 "Erreur : %v",
+		"error.rawMode":         "Erreur au passage en mode brut : %v",
+		"error.reenterRawMode":  "Erreur au retour en mode brut, arrêt...",
+		"error.restoreTerminal": "Erreur à la restauration du terminal : %v",
+		"error.readInput":       "Erreur de lecture : %v",
+		"error.endMeeting":      "Attention : impossible de clore la réunion : %v",
+		"error.loadTeam":        "Erreur au chargement de l'équipe : %v",
+		"error.emptyTeamFile":   "Aucun membre trouvé dans '%s'. Ajoutez les noms des membres (un par ligne).",
+		"error.emptyStdin":      "Aucun membre trouvé sur l'entrée standard. Fournissez les noms des membres (un par ligne).",
+		"hint.teamFile":         "Créez un fichier '%s' avec un nom de membre par ligne.",
+		"hint.stdin":            "Fournissez les noms des membres sur l'entrée standard (un par ligne).",
+
+		// Outcome of operations
+		"newRound":        "Tout le monde a déjà parlé. Nouveau tour...",
+		"pick":            "Au tour de... %s",
+		"manualPick":      "(choix manuel)",
+		"remaining.one":   "%d personne restante dans ce tour",
+		"remaining.other": "%d personnes restantes dans ce tour",
+		"lastPerson":      "C'était la dernière personne de ce tour",
+		"onDeck":          "Ensuite : %s",
+		"skipped":         "%s passe son tour et parlera en fin de tour.",
+		"absent":          "%s est marqué absent pour ce tour.",
+		"undid.pick":      "Choix de %s annulé, de retour en tête du tour.",
+		"undid.skip":      "Report de %s annulé, de retour en tête du tour.",
+		"undid.absence":   "Absence de %s annulée, de retour en tête du tour.",
+		"guestAdded":      "Bienvenue, %s ! Ajouté comme invité à ce tour.",
+		"reset.one":       "État réinitialisé ! %d membre de l'équipe peut être choisi.",
+		"reset.other":     "État réinitialisé ! Les %d membres de l'équipe peuvent être choisis.",
+		"announce":        "%s, à toi !",
+		"a11y.pick":       "Prochaine personne : %s.",
+		"a11y.manualPick": "Prochaine personne, choisie manuellement : %s.",
+		"guest":           "%s (invité)",
+
+		// Calendar export
+		"ics.calendar":  "Animation du daily scrum",
+		"ics.summary":   "Animation du daily scrum : %s",
+		"ics.tentative": "Provisoire : ce tour sera mélangé à son début.",
+
+		// Chat messages (Slack, webhooks)
+		"chat.newRound":        "Tout le monde a parlé, un nouveau tour commence.",
+		"chat.remaining.one":   "%d restant, ensuite : %s",
+		"chat.remaining.other": "%d restants, ensuite : %s",
+		"chat.lastPerson":      "c'était la dernière personne de ce tour",
+		"chat.skipped":         "%s passe son tour et parlera en fin de tour",
+		"chat.absent":          "%s est absent aujourd'hui",
+		"chat.undid.pick":      "Choix de %s annulé, de retour en tête du tour",
+		"chat.undid.skip":      "Report de %s annulé, de retour en tête du tour",
+		"chat.undid.absence":   "Absence de %s annulée, de retour en tête du tour",
+		"chat.reset":           "Le tour a été réinitialisé, tout le monde parlera à nouveau",
+		"chat.roundComplete":   "Tout le monde a parlé, le tour est terminé",
+		"chat.meetingEnd":      "Le stand-up est terminé",
+		"chat.status":          "Restants dans ce tour (%d sur %d) :",
+		"chat.help":            "Utilisation : `/standup next [nom]`, `skip [nom]`, `absent <nom>`, `undo`, `status` ou `reset`",
+		"chat.unknownCommand":  "Commande inconnue : '%s'. %s",
+
+		// Status
+		"status.heading":        "Statut :",
+		"status.title":          "Statut",
+		"status.total":          "Membres de l'équipe : %v",
+		"status.remaining":      "Restants dans ce tour : %v",
+		"status.stillToPick":    "Reste à choisir :",
+		"status.everyonePicked": "Tout le monde a été choisi dans ce tour",
+
+		// Help
+		"help.heading":     "Commandes disponibles :",
+		"help.title":       "Commandes disponibles",
+		"help.typing":      "En tapant les commandes (mode Entrée) :",
+		"help.key":         "Touche",
+		"help.command":     "Commande",
+		"help.description": "Description",
+		"help.pick":        "Choisir la prochaine personne du daily scrum",
+		"help.manual":      "Choisir qui passe ensuite (p. ex. quelqu'un qui doit partir tôt)",
+		"help.guest":       "Ajouter un invité à ce tour seulement (le fichier d'équipe n'est pas modifié)",
+		"help.undo":        "Annuler le dernier choix, report ou absence",
+		"help.reset":       "Réinitialiser l'état et recommencer avec toute l'équipe",
+		"help.status":      "Afficher le statut et les membres restants",
+		"help.help":        "Afficher cette aide",
+		"help.quit":        "Quitter le programme",
+		"help.pickName":    "Choisir un membre restant précis",
+		"help.skipName":    "Reporter quelqu'un (le suivant par défaut) à la fin du tour",
+		"help.absentName":  "Retirer un membre absent de ce tour",
+		"help.addGuest":    "Ajouter un invité à ce tour seulement",
+		"help.statusJSON":  "Afficher le statut en JSON",
+
+		// Errors
+		"cannot":                "Impossible (%s) : %s",
+		"cannot pick":           "Impossible de choisir : %s",
+		"cannot skip":           "Impossible de reporter : %s",
+		"cannot mark absent":    "Impossible de marquer absent : %s",
+		"cannot undo":           "Impossible d'annuler : %s",
+		"cannot reset":          "Impossible de réinitialiser : %s",
+		"cannot show status":    "Impossible d'afficher le statut : %s",
+		"cannot load the round": "Impossible de charger le tour : %s",
+		"cannot add guest":      "Impossible d'ajouter l'invité : %s",
+		"error.noName":          "aucun nom donné",
+		"error.nothingToUndo":   "rien à annuler",
+		"error.noMatch":         "personne ne correspond à '%s' parmi les restants",
+		"error.noSuchNumber":    "aucun membre restant avec le numéro %d (attendu de 1 à %d)",
+		"error.ambiguous":       "'%s' peut être %s",
+		"error.guestIsMember":   "%s fait déjà partie de l'équipe",
+		"error.alreadyInRound":  "%s est déjà dans ce tour",
+	},
+}
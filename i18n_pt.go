@@ -0,0 +1,133 @@
+package main
+
+// Brazilian Portuguese
+var portuguese = &language{
+	code: "pt",
+	// 0 and 1 are singular in Brazilian Portuguese
+	one: func(n int) bool {
+		return n == 0 || n == 1
+	},
+	strings: map[string]string{
+		// Interactive mode
+		"welcome.teamFile":      "Arquivo da equipe: %s",
+		"welcome.stdin":         "Equipe: entrada padrão",
+		"welcome.stateFile":     "Arquivo de estado: %s",
+		"welcome.historyFile":   "Arquivo de histórico: %s",
+		"members.one":           "%d membro",
+		"members.other":         "%d membros",
+		"commands":              "Comandos:",
+		"pressAnyKey":           "Pressione uma tecla (sem Enter):",
+		"typeCommands":          "Digite um comando e pressione Enter:",
+		"unknownKey":            "Comando desconhecido: '%s'. Pressione 'h' para ajuda.",
+		"unknownCommand":        "Comando desconhecido: '%s'. Digite 'h' para ajuda.",
+		"invalidCommand":        "Comando inválido: %v",
+		"goodbye":               "Tchau!",
+		"prompt.manualPick":     "Quem é o próximo? (nome ou número): ",
+		"prompt.guest":          "Nome do convidado: ",
+		"prompt.hint":           "(Tab completa nomes, Esc cancela)",
+		"manualPickCancelled":   "Escolha manual cancelada.",
+		"guestCancelled":        "Inclusão de convidado cancelada.",
+		"fallbackBuffered":      "Usando o modo linha a linha...",
+		"error":                 "Erro: %v",
+		"error.rawMode":         "Erro ao entrar no modo bruto: %v",
+		"error.reenterRawMode":  "Erro ao voltar ao modo bruto, saindo...",
+		"error.restoreTerminal": "Erro ao restaurar o terminal: %v",
+		"error.readInput":       "Erro ao ler a entrada: %v",
+		"error.endMeeting":      "Aviso: falha ao encerrar a reunião: %v",
+		"error.loadTeam":        "Erro ao carregar os membros da equipe: %v",
+		"error.emptyTeamFile":   "Nenhum membro encontrado em '%s'. Adicione os nomes dos membros (um por linha).",
+		"error.emptyStdin":      "Nenhum membro encontrado na entrada padrão. Informe os nomes dos membros (um por linha).",
+		"hint.teamFile":         "Crie um arquivo '%s' com um nome de membro por linha.",
+		"hint.stdin":            "Informe os nomes dos membros pela entrada padrão (um por linha).",
+
+		// Outcome of operations
+		"newRound":        "Todos já falaram. Reiniciando a lista...",
+		"pick":            "A próxima pessoa é... %s",
+		"manualPick":      "(escolha manual)",
+		"remaining.one":   "%d pessoa restante nesta rodada",
+		"remaining.other": "%d pessoas restantes nesta rodada",
+		"lastPerson":      "Essa foi a última pessoa desta rodada",
+		"onDeck":          "Em seguida: %s",
+		"skipped":         "%s foi adiado para o fim desta rodada.",
+		"absent":          "%s está marcado como ausente nesta rodada.",
+		"undid.pick":      "Escolha de %s desfeita, de volta ao início da rodada.",
+		"undid.skip":      "Adiamento de %s desfeito, de volta ao início da rodada.",
+		"undid.absence":   "Ausência de %s desfeita, de volta ao início da rodada.",
+		"guestAdded":      "Boas-vindas, %s! Incluído como convidado nesta rodada.",
+		"reset.one":       "Estado reiniciado! %d membro da equipe pode ser escolhido.",
+		"reset.other":     "Estado reiniciado! Todos os %d membros da equipe podem ser escolhidos.",
+		"announce":        "%s, sua vez!",
+		"a11y.pick":       "Próxima pessoa: %s.",
+		"a11y.manualPick": "Próxima pessoa, escolhida manualmente: %s.",
+		"guest":           "%s (convidado)",
+
+		// Calendar export
+		"ics.calendar":  "Facilitação da daily scrum",
+		"ics.summary":   "Facilitação da daily scrum: %s",
+		"ics.tentative": "Provisório: esta rodada será embaralhada quando começar.",
+
+		// Chat messages (Slack, webhooks)
+		"chat.newRound":        "Todos já falaram, então uma nova rodada começou.",
+		"chat.remaining.one":   "%d restante, em seguida: %s",
+		"chat.remaining.other": "%d restantes, em seguida: %s",
+		"chat.lastPerson":      "essa foi a última pessoa desta rodada",
+		"chat.skipped":         "%s foi adiado para o fim da rodada",
+		"chat.absent":          "%s está ausente hoje",
+		"chat.undid.pick":      "Escolha de %s desfeita, de volta ao início da rodada",
+		"chat.undid.skip":      "Adiamento de %s desfeito, de volta ao início da rodada",
+		"chat.undid.absence":   "Ausência de %s desfeita, de volta ao início da rodada",
+		"chat.reset":           "A rodada foi reiniciada, todos terão a vez novamente",
+		"chat.roundComplete":   "Todos já falaram, a rodada terminou",
+		"chat.meetingEnd":      "A daily terminou",
+		"chat.status":          "Restantes nesta rodada (%d de %d):",
+		"chat.help":            "Uso: `/standup next [nome]`, `skip [nome]`, `absent <nome>`, `undo`, `status` ou `reset`",
+		"chat.unknownCommand":  "Comando desconhecido: '%s'. %s",
+
+		// Status
+		"status.heading":        "Status:",
+		"status.title":          "Status",
+		"status.total":          "Membros da equipe: %v",
+		"status.remaining":      "Restantes nesta rodada: %v",
+		"status.stillToPick":    "Ainda falta escolher:",
+		"status.everyonePicked": "Todos foram escolhidos nesta rodada",
+
+		// Help
+		"help.heading":     "Comandos disponíveis:",
+		"help.title":       "Comandos disponíveis",
+		"help.typing":      "Digitando comandos (modo Enter):",
+		"help.key":         "Tecla",
+		"help.command":     "Comando",
+		"help.description": "Descrição",
+		"help.pick":        "Escolher a próxima pessoa da daily scrum",
+		"help.manual":      "Escolher quem é o próximo (ex.: alguém que precisa sair mais cedo)",
+		"help.guest":       "Incluir um convidado só nesta rodada (o arquivo da equipe não muda)",
+		"help.undo":        "Desfazer a última escolha, adiamento ou ausência",
+		"help.reset":       "Reiniciar o estado e recomeçar com toda a equipe",
+		"help.status":      "Mostrar o status e os membros restantes",
+		"help.help":        "Mostrar esta ajuda",
+		"help.quit":        "Sair do programa",
+		"help.pickName":    "Escolher um membro restante específico",
+		"help.skipName":    "Adiar alguém (o próximo por padrão) para o fim da rodada",
+		"help.absentName":  "Remover um membro ausente desta rodada",
+		"help.addGuest":    "Incluir um convidado só nesta rodada",
+		"help.statusJSON":  "Mostrar o status em JSON",
+
+		// Errors
+		"cannot":                "Não foi possível (%s): %s",
+		"cannot pick":           "Não foi possível escolher: %s",
+		"cannot skip":           "Não foi possível adiar: %s",
+		"cannot mark absent":    "Não foi possível marcar ausência: %s",
+		"cannot undo":           "Não foi possível desfazer: %s",
+		"cannot reset":          "Não foi possível reiniciar: %s",
+		"cannot show status":    "Não foi possível mostrar o status: %s",
+		"cannot load the round": "Não foi possível carregar a rodada: %s",
+		"cannot add guest":      "Não foi possível incluir o convidado: %s",
+		"error.noName":          "nenhum nome informado",
+		"error.nothingToUndo":   "nada para desfazer",
+		"error.noMatch":         "ninguém restante corresponde a '%s'",
+		"error.noSuchNumber":    "nenhum membro restante com o número %d (esperado de 1 a %d)",
+		"error.ambiguous":       "'%s' pode ser %s",
+		"error.guestIsMember":   "%s já é membro da equipe",
+		"error.alreadyInRound":  "%s já está nesta rodada",
+	},
+}
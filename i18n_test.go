@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/rm3l/daily-scrum-picker/picker"
+)
+
+func TestSelectLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		flag     string
+		env      map[string]string
+		expected string
+		wantErr  bool
+	}{
+		{name: "default", expected: "en"},
+		{name: "LANG", env: map[string]string{"LANG": "fr_FR.UTF-8"}, expected: "fr"},
+		{name: "LC_ALL wins over LANG", env: map[string]string{"LC_ALL": "pt_BR.UTF-8", "LANG": "fr_FR.UTF-8"}, expected: "pt"},
+		{name: "LC_MESSAGES wins over LANG", env: map[string]string{"LC_MESSAGES": "pt-BR", "LANG": "fr_FR"}, expected: "pt"},
+		{name: "unsupported locale", env: map[string]string{"LC_ALL": "C", "LANG": "fr_FR.UTF-8"}, expected: "en"},
+		{name: "flag wins over the environment", flag: "FR", env: map[string]string{"LANG": "pt_BR.UTF-8"}, expected: "fr"},
+		{name: "unknown flag", flag: "de", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+				t.Setenv(key, tt.env[key])
+			}
+			lang, err := selectLanguage(tt.flag)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error, got %s", lang.code)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectLanguage failed: %v", err)
+			}
+			if lang.code != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, lang.code)
+			}
+		})
+	}
+}
+
+func TestPlurals(t *testing.T) {
+	tests := []struct {
+		lang     *language
+		n        int
+		expected string
+	}{
+		{lang: english, n: 1, expected: "1 person remaining in this round"},
+		{lang: english, n: 4, expected: "4 people remaining in this round"},
+		{lang: english, n: 0, expected: "0 people remaining in this round"},
+		{lang: french, n: 0, expected: "0 personne restante dans ce tour"},
+		{lang: french, n: 2, expected: "2 personnes restantes dans ce tour"},
+		{lang: portuguese, n: 1, expected: "1 pessoa restante nesta rodada"},
+		{lang: portuguese, n: 3, expected: "3 pessoas restantes nesta rodada"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.lang.T("remaining", tt.n); got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.lang.code, tt.expected, got)
+		}
+	}
+}
+
+var formatVerb = regexp.MustCompile(`%(\[\d+\])?[-+# 0-9.]*[a-zA-Z%]`)
+
+// Every string is translated, with the same arguments as in English
+func TestCatalogsComplete(t *testing.T) {
+	for _, lang := range languages {
+		for id, format := range english.strings {
+			translated, ok := lang.strings[id]
+			if !ok {
+				t.Errorf("%s: missing %q", lang.code, id)
+				continue
+			}
+			verbs, translatedVerbs := formatVerb.FindAllString(format, -1), formatVerb.FindAllString(translated, -1)
+			slices.Sort(verbs)
+			slices.Sort(translatedVerbs)
+			if !slices.Equal(verbs, translatedVerbs) {
+				t.Errorf("%s: %q expects %v, got %v", lang.code, id, verbs, translatedVerbs)
+			}
+		}
+		for id := range lang.strings {
+			if _, ok := english.strings[id]; !ok {
+				t.Errorf("%s: unknown string %q", lang.code, id)
+			}
+		}
+	}
+}
+
+func TestTranslatedOutput(t *testing.T) {
+	var buf bytes.Buffer
+	r, err := newRenderer(&buf, renderOptions{color: ColorModeNever, noEmoji: true, lang: french})
+	if err != nil {
+		t.Fatalf("newRenderer failed: %v", err)
+	}
+	r.Pick(pickData{Name: "Alice", Action: picker.ActionManualPick, Manual: true, Remaining: []string{"Bob"}})
+	r.Error("pick", &picker.MemberError{Err: picker.ErrAmbiguousMember, Input: "al", Matches: []string{"Alice", "Albert"}})
+	r.Error("add guest", &guestError{name: "Carol", err: picker.ErrAlreadyInRound})
+
+	for _, expected := range []string{
+		"Au tour de... Alice (choix manuel)\n",
+		"(1 personne restante dans ce tour)\n",
+		"Impossible de choisir : 'al' peut être Alice, Albert\n",
+		"Impossible d'ajouter l'invité : Carol est déjà dans ce tour\n",
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, buf.String())
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rm3l/daily-scrum-picker/picker"
+)
+
+// How long clients wait before reconnecting to the event stream
+const sseRetry = 3 * time.Second
+
+// Message queued for a Server-Sent Events client
+type sseMessage struct {
+	id    int
+	event string
+	data  []byte
+}
+
+// Fans out messages to every connected client. A client too slow to keep up
+// is disconnected: it reconnects and gets the latest state again.
+type broadcaster struct {
+	mu      sync.Mutex
+	clients map[chan sseMessage]struct{}
+	lastID  int
+}
+
+func newBroadcaster() *broadcaster {
+	return &broadcaster{clients: make(map[chan sseMessage]struct{})}
+}
+
+func (b *broadcaster) subscribe() chan sseMessage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ch := make(chan sseMessage, 32)
+	b.clients[ch] = struct{}{}
+	return ch
+}
+
+func (b *broadcaster) unsubscribe(ch chan sseMessage) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.clients[ch]; ok {
+		delete(b.clients, ch)
+		close(ch)
+	}
+}
+
+// Disconnect all clients, e.g. on shutdown
+func (b *broadcaster) closeAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.clients {
+		delete(b.clients, ch)
+		close(ch)
+	}
+}
+
+func (b *broadcaster) publish(event string, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("Error encoding %s event: %v", event, err)
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.lastID++
+	msg := sseMessage{id: b.lastID, event: event, data: data}
+	for ch := range b.clients {
+		select {
+		case ch <- msg:
+		default:
+			delete(b.clients, ch)
+			close(ch)
+		}
+	}
+}
+
+func (b *broadcaster) currentID() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.lastID
+}
+
+// Everything a live client needs to render the stand-up
+type liveState struct {
+	picker.Status
+	Members        []string   `json:"members"`
+	Speaker        string     `json:"speaker,omitempty"`
+	SpeakingSince  *time.Time `json:"speakingSince,omitempty"`
+	ElapsedSeconds int        `json:"elapsedSeconds"`
+}
+
+// Sent to live clients for every change: what happened and the resulting state
+type liveUpdate struct {
+	Event picker.Event `json:"event"`
+	State liveState    `json:"state"`
+}
+
+// Timer of the current speaker, sent periodically to live clients
+type liveTick struct {
+	Speaker        string `json:"speaker"`
+	ElapsedSeconds int    `json:"elapsedSeconds"`
+}
+
+// Must be called with srv.mu held
+func (srv *server) liveState(status picker.Status) liveState {
+	state := liveState{Status: status, Members: srv.session.TeamMembers()}
+	if srv.speaker != "" {
+		since := srv.speakingSince
+		state.Speaker = srv.speaker
+		state.SpeakingSince = &since
+		state.ElapsedSeconds = int(time.Since(since).Seconds())
+	}
+	return state
+}
+
+// Session listener, called with srv.mu held by the handler making the change
+func (srv *server) onEvent(event picker.Event) {
+	switch {
+	case event.Type == picker.EventPick:
+		srv.speaker, srv.speakingSince = event.Name, event.Time
+	case event.Type == picker.EventReset,
+		event.Type == picker.EventUndo && event.Name == srv.speaker,
+		event.Type == picker.EventAbsent && event.Name == srv.speaker:
+		srv.speaker = ""
+	}
+
+	status := picker.Status{
+		TeamMembers: len(srv.session.TeamMembers()),
+		Remaining:   event.Remaining,
+		Guests:      srv.session.Guests(),
+	}
+	srv.events.publish(event.Type, liveUpdate{Event: event, State: srv.liveState(status)})
+}
+
+// Broadcast the current speaker's timer until ctx is done
+func (srv *server) runTicker(ctx context.Context) {
+	ticker := time.NewTicker(srv.tickInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			srv.mu.Lock()
+			speaker, since := srv.speaker, srv.speakingSince
+			srv.mu.Unlock()
+			if speaker != "" {
+				srv.events.publish("tick", liveTick{Speaker: speaker, ElapsedSeconds: int(time.Since(since).Seconds())})
+			}
+		}
+	}
+}
+
+// Stream changes as Server-Sent Events. Every (re)connection starts with the
+// full current state, so clients resume from the latest state after a drop.
+func (srv *server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	// Subscribe first, so that no change is missed between the snapshot and the stream
+	ch := srv.events.subscribe()
+	defer srv.events.unsubscribe(ch)
+
+	srv.mu.Lock()
+	status, err := srv.session.Status()
+	var snapshot []byte
+	if err == nil {
+		snapshot, err = json.Marshal(srv.liveState(status))
+	}
+	srv.mu.Unlock()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	rc := http.NewResponseController(w)
+	_, _ = fmt.Fprintf(w, "retry: %d\n\n", sseRetry.Milliseconds())
+	if err := writeSSE(w, sseMessage{id: srv.events.currentID(), event: "state", data: snapshot}); err != nil {
+		return
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			if err := writeSSE(w, msg); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func writeSSE(w io.Writer, msg sseMessage) error {
+	_, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", msg.id, msg.event, msg.data)
+	return err
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/rm3l/daily-scrum-picker/picker"
+)
+
+// Layout of the messages of text output, as text/template strings that can be
+// overridden in the config file. Besides the data of each message, templates
+// can call tr (e.g. {{tr "pick" .Name}}, with the strings of the language),
+// plural (e.g. {{plural .TeamMembers "%d member" "%d members"}}), color (e.g.
+// {{color "name" .Name}}) and emoji (e.g. {{emoji "🎯 "}}), which honor
+// --lang, --color, --no-emoji and the theme.
+var defaultMessages = map[string]string{
+	// welcomeData
+	"welcome": `=== Daily Scrum Picker ===
+{{if .Stdin}}{{tr "welcome.stdin"}}{{else}}{{tr "welcome.teamFile" .TeamFile}}{{end}} ({{tr "members" .TeamMembers}})
+{{tr "welcome.stateFile" .StateFile}}
+{{tr "welcome.historyFile" .HistoryFile}}`,
+	// pickData
+	"newRound":   `{{tr "newRound"}}`,
+	"pick":       `{{emoji "🎯 "}}{{tr "pick" (color "name" .Name)}}{{if .Manual}} {{tr "manualPick"}}{{end}}`,
+	"remaining":  `{{color "remaining" (print "(" (tr "remaining" (len .Remaining)) ")")}}`,
+	"lastPerson": `{{color "success" (print "(" (tr "lastPerson") ")")}}`,
+	"onDeck":     `{{color "accent" (tr "onDeck" (join .OnDeck ", "))}}`,
+	// resetData
+	"reset": `{{color "success" (print (emoji "✅ ") (tr "reset" .TeamMembers))}}`,
+	// statusData
+	"status": `{{color "heading" (print (emoji "📊 ") (tr "status.heading"))}}
+  {{tr "status.total" (color "info" .TeamMembers)}}
+  {{tr "status.remaining" (color "remaining" (len .Remaining))}}
+{{- if .Remaining}}
+  {{tr "status.stillToPick"}}
+{{- range $i, $name := .Remaining}}
+  {{color "accent" (printf "%2d." (inc $i))}} {{if $.IsGuest $name}}{{tr "guest" $name}}{{else}}{{$name}}{{end}}
+{{- end}}
+{{- else}}
+  {{color "success" (tr "status.everyonePicked")}}
+{{- end}}`,
+}
+
+// Messages of the --accessible mode: plain sentences, one per line, that
+// screen readers read without decorations
+var accessibleMessages = map[string]string{
+	"welcome": `Daily Scrum Picker.
+{{if .Stdin}}{{tr "welcome.stdin"}}{{else}}{{tr "welcome.teamFile" .TeamFile}}{{end}}, {{tr "members" .TeamMembers}}.
+{{tr "welcome.stateFile" .StateFile}}.
+{{tr "welcome.historyFile" .HistoryFile}}.`,
+	"pick":       `{{if .Manual}}{{tr "a11y.manualPick" .Name}}{{else}}{{tr "a11y.pick" .Name}}{{end}}`,
+	"remaining":  `{{tr "remaining" (len .Remaining)}}.`,
+	"lastPerson": `{{tr "lastPerson"}}.`,
+	"onDeck":     `{{tr "onDeck" (join .OnDeck ", ")}}.`,
+	"status": `{{tr "status.heading"}} {{tr "status.total" .TeamMembers}}. {{tr "status.remaining" (len .Remaining)}}.
+{{- if .Remaining}}
+{{tr "status.stillToPick"}}
+{{- range $i, $name := .Remaining}}
+{{inc $i}}. {{if $.IsGuest $name}}{{tr "guest" $name}}{{else}}{{$name}}{{end}}.
+{{- end}}
+{{- else}}
+{{tr "status.everyonePicked"}}.
+{{- end}}`,
+}
+
+// Data of the welcome banner of the interactive mode
+type welcomeData struct {
+	// Path of the team file, unless the team is read from stdin
+	TeamFile    string
+	Stdin       bool
+	TeamMembers int
+	StateFile   string
+	HistoryFile string
+}
+
+// Data of the pick messages
+type pickData struct {
+	Name string
+	// History action of the pick, random or manual
+	Action string
+	Manual bool
+	// A new round was started for this pick
+	NewRound bool
+	// Members still to speak after this one, in order
+	Remaining []string
+	// Next speakers, if previews are enabled
+	OnDeck []string
+	// Round of this run, from 1
+	Round int
+	// Place of the speaker in the round, from 1; absent members count as
+	// having had their turn
+	Position int
+	// Previous speaker of this run in the round, and how long they had the
+	// floor, if known
+	Previous string
+	Elapsed  time.Duration
+}
+
+func (d pickData) result() picker.PickResult {
+	return picker.PickResult{Name: d.Name, Action: d.Action, Remaining: d.Remaining, NewRound: d.NewRound}
+}
+
+type resetData struct {
+	TeamMembers int
+}
+
+type statusData struct {
+	picker.Status
+}
+
+func (d statusData) IsGuest(name string) bool {
+	return slices.Contains(d.Guests, name)
+}
+
+// Sample data, to check overridden templates when loading the config
+var sampleMessageData = map[string]any{
+	"welcome": welcomeData{TeamFile: "team.txt", TeamMembers: 2, StateFile: "state.txt", HistoryFile: "history.txt"},
+	"reset":   resetData{TeamMembers: 2},
+	"status":  statusData{picker.Status{TeamMembers: 2, Remaining: []string{"Bob"}}},
+}
+
+func sampleData(key string) any {
+	if data, ok := sampleMessageData[key]; ok {
+		return data
+	}
+	return pickData{Name: "Alice", Remaining: []string{"Bob"}, OnDeck: []string{"Bob"}, Round: 1, Position: 1}
+}
+
+// Functions available to templates, styling text as r would
+func messageFuncs(r *textRenderer) template.FuncMap {
+	return template.FuncMap{
+		"color": func(role string, value any) string {
+			return r.style(role, fmt.Sprint(value))
+		},
+		"tr":     r.lang.T,
+		"plural": r.lang.plural,
+		"emoji": func(emoji string) string {
+			return icon(r.emoji, emoji)
+		},
+		"join": strings.Join,
+		"inc": func(i int) int {
+			return i + 1
+		},
+	}
+}
+
+// Parse the default messages, with overrides on top
+func parseMessages(overrides map[string]string, funcs template.FuncMap) (map[string]*template.Template, error) {
+	sources := maps.Clone(defaultMessages)
+	for key, source := range overrides {
+		if _, ok := defaultMessages[key]; !ok {
+			return nil, fmt.Errorf("unknown message '%s' (expected one of %s)",
+				key, strings.Join(slices.Sorted(maps.Keys(defaultMessages)), ", "))
+		}
+		sources[key] = source
+	}
+
+	messages := make(map[string]*template.Template, len(sources))
+	for key, source := range sources {
+		tmpl, err := template.New(key).Funcs(funcs).Parse(source)
+		if err != nil {
+			return nil, fmt.Errorf("invalid message '%s': %w", key, err)
+		}
+		if err := tmpl.Execute(io.Discard, sampleData(key)); err != nil {
+			return nil, fmt.Errorf("invalid message '%s': %w", key, err)
+		}
+		messages[key] = tmpl
+	}
+	return messages, nil
+}
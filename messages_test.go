@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rm3l/daily-scrum-picker/picker"
+)
+
+func TestMessageOverrides(t *testing.T) {
+	var buf bytes.Buffer
+	r, err := newRenderer(&buf, renderOptions{
+		color: ColorModeNever,
+		messages: map[string]string{
+			"pick":      `🎤 Over to you, {{.Name}}! ({{.Position}} of round {{.Round}}{{if .Previous}}, {{.Previous}} spoke for {{.Elapsed}}{{end}})`,
+			"remaining": ``,
+		},
+	})
+	if err != nil {
+		t.Fatalf("newRenderer failed: %v", err)
+	}
+
+	r.Pick(pickData{Name: "Bob", Remaining: []string{"Carol"}, Round: 2, Position: 2, Previous: "Alice", Elapsed: 95 * time.Second})
+	expected := "🎤 Over to you, Bob! (2 of round 2, Alice spoke for 1m35s)\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestParseMessages_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages map[string]string
+		contains string
+	}{
+		{name: "unknown message", messages: map[string]string{"goodbye": "Bye"}, contains: "unknown message 'goodbye'"},
+		{name: "syntax error", messages: map[string]string{"pick": "{{.Name"}, contains: "invalid message 'pick'"},
+		{name: "unknown field", messages: map[string]string{"reset": "{{.Name}}"}, contains: "invalid message 'reset'"},
+		{name: "unknown function", messages: map[string]string{"pick": "{{shout .Name}}"}, contains: "invalid message 'pick'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseMessages(tt.messages, messageFuncs(&textRenderer{lang: english}))
+			if err == nil || !strings.Contains(err.Error(), tt.contains) {
+				t.Errorf("Expected error containing %q, got %v", tt.contains, err)
+			}
+		})
+	}
+}
+
+func TestSessionPickData(t *testing.T) {
+	s := newTestSession(t, "Alice", "Bob", "Carol")
+	setTestRound(t, s, "Alice", "Bob", "Carol")
+
+	first := s.pickData(mustPick(t, s))
+	if first.Round != 1 || first.Position != 1 || first.Previous != "" {
+		t.Errorf("Unexpected first pick: %+v", first)
+	}
+	second := s.pickData(mustPick(t, s))
+	if second.Round != 1 || second.Position != 2 || second.Previous != first.Name {
+		t.Errorf("Unexpected second pick: %+v", second)
+	}
+
+	resetState(s)
+	afterReset := s.pickData(mustPick(t, s))
+	if afterReset.Round != 2 || afterReset.Position != 1 || afterReset.Previous != "" {
+		t.Errorf("Expected a new round after a reset, got %+v", afterReset)
+	}
+}
+
+func TestSessionPickData_RoundWithGuest(t *testing.T) {
+	s := newTestSession(t, "Alice", "Bob", "Carol")
+	setTestRound(t, s, "Alice", "Bob", "Carol")
+	if err := s.AddGuest("Zed"); err != nil {
+		t.Fatalf("AddGuest failed: %v", err)
+	}
+
+	for want := 1; want <= 4; want++ {
+		if data := s.pickData(mustPick(t, s)); data.Position != want {
+			t.Errorf("Expected %s to speak in position %d, got %d", data.Name, want, data.Position)
+		}
+	}
+}
+
+func mustPick(t *testing.T, s *session) picker.PickResult {
+	t.Helper()
+	result, err := s.Pick("")
+	if err != nil {
+		t.Fatalf("Pick failed: %v", err)
+	}
+	return result
+}
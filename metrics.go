@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rm3l/daily-scrum-picker/picker"
+)
+
+// Buckets of the speaker and meeting duration histograms, in seconds
+var (
+	speakerDurationBuckets = []float64{15, 30, 60, 90, 120, 180, 300, 600}
+	meetingDurationBuckets = []float64{60, 120, 300, 600, 900, 1200, 1800, 3600}
+)
+
+// Stand-up metrics of every room, served in the Prometheus text format
+type metrics struct {
+	mu               sync.Mutex
+	picks            *metricVec
+	skips            *metricVec
+	absences         *metricVec
+	resets           *metricVec
+	roundsCompleted  *metricVec
+	speakerDurations *metricVec
+	meetingDurations *metricVec
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		picks:            newCounterVec("daily_scrum_picks_total", "Members picked to speak.", "room", "member", "action"),
+		skips:            newCounterVec("daily_scrum_skips_total", "Members moved to the end of the round.", "room", "member"),
+		absences:         newCounterVec("daily_scrum_absences_total", "Members marked away for a round.", "room", "member"),
+		resets:           newCounterVec("daily_scrum_resets_total", "Rounds reset before everyone had a turn.", "room"),
+		roundsCompleted:  newCounterVec("daily_scrum_rounds_completed_total", "Rounds where everyone had a turn.", "room"),
+		speakerDurations: newHistogramVec("daily_scrum_speaker_duration_seconds", "Time each speaker had the floor, until the next pick.", speakerDurationBuckets, "room", "member"),
+		meetingDurations: newHistogramVec("daily_scrum_meeting_duration_seconds", "Time from the first to the last pick of a round.", meetingDurationBuckets, "room"),
+	}
+}
+
+// Record the events of a room's session
+func (m *metrics) track(room string, s *session) {
+	s.Subscribe(m.listener(room))
+}
+
+// Session listener recording the events of a room. The last speaker of a
+// round is not timed, as nothing marks the end of their turn.
+func (m *metrics) listener(room string) func(picker.Event) {
+	var speaker string
+	var speakingSince, meetingStart time.Time
+
+	return func(event picker.Event) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		switch event.Type {
+		case picker.EventPick:
+			// A new round means the previous speaker was the last one of a
+			// past meeting
+			if speaker != "" && !event.NewRound {
+				m.speakerDurations.observe(event.Time.Sub(speakingSince).Seconds(), room, speaker)
+			}
+			if meetingStart.IsZero() || event.NewRound {
+				meetingStart = event.Time
+			}
+			speaker, speakingSince = event.Name, event.Time
+			m.picks.inc(room, event.Name, event.Action)
+		case picker.EventSkip:
+			m.skips.inc(room, event.Name)
+		case picker.EventAbsent:
+			m.absences.inc(room, event.Name)
+			if event.Name == speaker {
+				speaker = ""
+			}
+		case picker.EventUndo:
+			// The turn of a speaker picked by mistake is not timed
+			if event.Name == speaker {
+				speaker = ""
+			}
+		case picker.EventReset:
+			m.resets.inc(room)
+			speaker, meetingStart = "", time.Time{}
+		case picker.EventRoundComplete:
+			m.roundsCompleted.inc(room)
+			if !meetingStart.IsZero() {
+				m.meetingDurations.observe(event.Time.Sub(meetingStart).Seconds(), room)
+			}
+			meetingStart = time.Time{}
+		}
+	}
+}
+
+func (m *metrics) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	for _, v := range []*metricVec{
+		m.picks, m.skips, m.absences, m.resets, m.roundsCompleted, m.speakerDurations, m.meetingDurations,
+	} {
+		if err := v.write(w); err != nil {
+			log.Printf("Error writing metrics: %v", err)
+			return
+		}
+	}
+}
+
+// Counter or histogram with one series per combination of label values
+type metricVec struct {
+	name   string
+	help   string
+	kind   string
+	labels []string
+	// Upper bounds of histogram buckets, in increasing order
+	buckets []float64
+	series  map[string]*metricSeries
+}
+
+type metricSeries struct {
+	labelValues []string
+	// Value of a counter, or number of observations of a histogram
+	count float64
+	sum   float64
+	// Observations in each histogram bucket, not cumulated
+	bucketCounts []uint64
+}
+
+func newCounterVec(name, help string, labels ...string) *metricVec {
+	return &metricVec{name: name, help: help, kind: "counter", labels: labels, series: make(map[string]*metricSeries)}
+}
+
+func newHistogramVec(name, help string, buckets []float64, labels ...string) *metricVec {
+	v := newCounterVec(name, help, labels...)
+	v.kind, v.buckets = "histogram", buckets
+	return v
+}
+
+func (v *metricVec) get(labelValues []string) *metricSeries {
+	key := strings.Join(labelValues, "\x00")
+	s, ok := v.series[key]
+	if !ok {
+		s = &metricSeries{labelValues: labelValues, bucketCounts: make([]uint64, len(v.buckets))}
+		v.series[key] = s
+	}
+	return s
+}
+
+func (v *metricVec) inc(labelValues ...string) {
+	v.get(labelValues).count++
+}
+
+func (v *metricVec) observe(value float64, labelValues ...string) {
+	s := v.get(labelValues)
+	s.count++
+	s.sum += value
+	if i, _ := slices.BinarySearch(v.buckets, value); i < len(v.buckets) {
+		s.bucketCounts[i]++
+	}
+}
+
+// Write the metric in the Prometheus text exposition format, with series
+// sorted by label values
+func (v *metricVec) write(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", v.name, v.help, v.name, v.kind)
+
+	keys := make([]string, 0, len(v.series))
+	for key := range v.series {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		s := v.series[key]
+		labels := formatLabels(v.labels, s.labelValues)
+		if v.kind == "counter" {
+			fmt.Fprintf(&b, "%s%s %s\n", v.name, labels, formatValue(s.count))
+			continue
+		}
+
+		var cumulative uint64
+		for i, upper := range v.buckets {
+			cumulative += s.bucketCounts[i]
+			le := formatLabels(append(slices.Clone(v.labels), "le"), append(slices.Clone(s.labelValues), formatValue(upper)))
+			fmt.Fprintf(&b, "%s_bucket%s %d\n", v.name, le, cumulative)
+		}
+		le := formatLabels(append(slices.Clone(v.labels), "le"), append(slices.Clone(s.labelValues), "+Inf"))
+		fmt.Fprintf(&b, "%s_bucket%s %s\n", v.name, le, formatValue(s.count))
+		fmt.Fprintf(&b, "%s_sum%s %s\n", v.name, labels, formatValue(s.sum))
+		fmt.Fprintf(&b, "%s_count%s %s\n", v.name, labels, formatValue(s.count))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// Labels as {name="value",...}, or nothing without labels
+func formatLabels(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = fmt.Sprintf(`%s="%s"`, name, labelEscaper.Replace(values[i]))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
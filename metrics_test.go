@@ -0,0 +1,141 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rm3l/daily-scrum-picker/picker"
+)
+
+func scrapeMetrics(t *testing.T, url, token string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url+"/metrics", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			t.Logf("Warning: failed to close response body: %v", err)
+		}
+	}()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read metrics: %v", err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestMetrics_CountsRoomEvents(t *testing.T) {
+	_, ts := newTestHub(t, map[string]string{
+		"payments.txt": "Alice\nBob\nCharlie\n",
+		"search.txt":   "Diana\n",
+	})
+
+	doJSON(t, http.MethodPost, ts.URL+"/rooms/payments/api/pick", `{"name": "Alice"}`, nil)
+	doJSON(t, http.MethodPost, ts.URL+"/rooms/payments/api/skip", `{"name": "Bob"}`, nil)
+	doJSON(t, http.MethodPost, ts.URL+"/rooms/payments/api/absent", `{"name": "Charlie"}`, nil)
+	doJSON(t, http.MethodPost, ts.URL+"/rooms/payments/api/pick", "", nil)
+	doJSON(t, http.MethodPost, ts.URL+"/rooms/search/api/pick", "", nil)
+	doJSON(t, http.MethodPost, ts.URL+"/rooms/search/api/reset", "", nil)
+
+	status, body := scrapeMetrics(t, ts.URL, "")
+	if status != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", status)
+	}
+	for _, line := range []string{
+		`daily_scrum_picks_total{room="payments",member="Alice",action="manual"} 1`,
+		`daily_scrum_picks_total{room="payments",member="Bob",action="random"} 1`,
+		`daily_scrum_picks_total{room="search",member="Diana",action="random"} 1`,
+		`daily_scrum_skips_total{room="payments",member="Bob"} 1`,
+		`daily_scrum_absences_total{room="payments",member="Charlie"} 1`,
+		`daily_scrum_resets_total{room="search"} 1`,
+		`daily_scrum_rounds_completed_total{room="payments"} 1`,
+		`daily_scrum_rounds_completed_total{room="search"} 1`,
+		`daily_scrum_speaker_duration_seconds_count{room="payments",member="Alice"} 1`,
+		`daily_scrum_meeting_duration_seconds_count{room="payments"} 1`,
+		`# TYPE daily_scrum_speaker_duration_seconds histogram`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", line, body)
+		}
+	}
+	// The last speaker of the round is not timed
+	if strings.Contains(body, `daily_scrum_speaker_duration_seconds_count{room="payments",member="Bob"}`) {
+		t.Errorf("Expected no duration for the last speaker, got:\n%s", body)
+	}
+}
+
+func TestMetrics_SpeakerAndMeetingDurations(t *testing.T) {
+	m := newMetrics()
+	listen := m.listener("payments")
+
+	start := time.Date(2025, 7, 24, 9, 30, 0, 0, time.UTC)
+	for _, event := range []picker.Event{
+		{Type: picker.EventPick, Name: "Alice", Action: picker.ActionRandomPick, Time: start},
+		{Type: picker.EventPick, Name: "Bob", Action: picker.ActionRandomPick, Time: start.Add(45 * time.Second)},
+		{Type: picker.EventRoundComplete, Time: start.Add(45 * time.Second)},
+		// Next day: Bob's turn is not counted as lasting until then
+		{Type: picker.EventPick, Name: "Alice", Action: picker.ActionRandomPick, Time: start.Add(24 * time.Hour), NewRound: true},
+		{Type: picker.EventUndo, Name: "Alice", Action: picker.ActionRandomPick, Time: start.Add(24*time.Hour + time.Second)},
+		{Type: picker.EventPick, Name: "Bob", Action: picker.ActionManualPick, Time: start.Add(24*time.Hour + 2*time.Second)},
+	} {
+		listen(event)
+	}
+
+	rec := httptest.NewRecorder()
+	m.handleMetrics(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+	for _, line := range []string{
+		`daily_scrum_speaker_duration_seconds_bucket{room="payments",member="Alice",le="30"} 0`,
+		`daily_scrum_speaker_duration_seconds_bucket{room="payments",member="Alice",le="60"} 1`,
+		`daily_scrum_speaker_duration_seconds_bucket{room="payments",member="Alice",le="+Inf"} 1`,
+		`daily_scrum_speaker_duration_seconds_sum{room="payments",member="Alice"} 45`,
+		`daily_scrum_speaker_duration_seconds_count{room="payments",member="Alice"} 1`,
+		`daily_scrum_meeting_duration_seconds_sum{room="payments"} 45`,
+		`daily_scrum_meeting_duration_seconds_count{room="payments"} 1`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", line, body)
+		}
+	}
+	if strings.Contains(body, `daily_scrum_speaker_duration_seconds_count{room="payments",member="Bob"}`) {
+		t.Errorf("Expected no duration for Bob, got:\n%s", body)
+	}
+	if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/plain; version=0.0.4") {
+		t.Errorf("Expected the Prometheus text format, got %q", got)
+	}
+}
+
+func TestMetrics_Token(t *testing.T) {
+	h, ts := newTestHub(t, map[string]string{"payments.txt": "Alice\n"})
+	h.metricsAccess = access{facilitator: "prom", viewer: "prom"}
+
+	if status, _ := scrapeMetrics(t, ts.URL, ""); status != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without a token, got %d", status)
+	}
+	if status, _ := scrapeMetrics(t, ts.URL, "prom"); status != http.StatusOK {
+		t.Errorf("Expected 200 with the metrics token, got %d", status)
+	}
+}
+
+func TestFormatLabels(t *testing.T) {
+	got := formatLabels([]string{"room", "member"}, []string{"payments", "Ann \"The\" \\ Lee\n"})
+	expected := `{room="payments",member="Ann \"The\" \\ Lee\n"}`
+	if got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+	if got := formatLabels(nil, nil); got != "" {
+		t.Errorf("Expected no labels, got %s", got)
+	}
+}
@@ -1,46 +1,21 @@
 package main
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
-	"math/rand"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
-)
 
-// ANSI color codes - universal colors for both dark and light terminals
-const (
-	ColorReset = "\033[0m"
-
-	// Standard colors that work universally
-	ColorRed    = "\033[31m" // Good on both backgrounds
-	ColorGreen  = "\033[32m" // Good on both backgrounds
-	ColorBlue   = "\033[34m" // Good on both backgrounds
-	ColorPurple = "\033[35m" // Good on both backgrounds
-
-	// Text formatting
-	Bold      = "\033[1m"
-	Underline = "\033[4m"
-
-	// Universal color combinations - tested for readability on both backgrounds
-	BoldRed    = "\033[1;31m" // Excellent on both
-	BoldGreen  = "\033[1;32m" // Excellent on both
-	BoldBlue   = "\033[1;34m" // Excellent on both
-	BoldPurple = "\033[1;35m" // Excellent on both
-
-	// Using darker variants for even better contrast
-	DarkRed   = "\033[38;5;124m" // Dark red - great on both
-	DarkGreen = "\033[38;5;22m"  // Dark green - great on both
-	DarkBlue  = "\033[38;5;18m"  // Dark blue - great on both
-	BrightRed = "\033[91m"       // Bright red - good on both
+	"github.com/rm3l/daily-scrum-picker/picker"
 )
 
-const goodbyeMessage = "Goodbye!"
-
 func getTeamFile(flagValue string) string {
 	// Command-line flag takes precedence (standard practice)
 	if flagValue != "" {
@@ -68,58 +43,193 @@ var rootCmd = &cobra.Command{
 	Run:   runApp,
 }
 
-var teamFileFlag string
+var (
+	teamFileFlag   string
+	noPreviewFlag  bool
+	outputFlag     string
+	colorFlag      string
+	noEmojiFlag    bool
+	langFlag       string
+	animateFlag    bool
+	announceFlag   bool
+	accessibleFlag bool
+	animateFor     time.Duration
+	guestFlags     []string
+)
 
 func init() {
-	rootCmd.Flags().StringVarP(&teamFileFlag, "team-file", "t", "", "Path to team members file, or '-' for stdin (overrides TEAM_FILE environment variable)")
+	rootCmd.PersistentFlags().StringVarP(&teamFileFlag, "team-file", "t", "", "Path to team members file, or '-' for stdin (overrides TEAM_FILE environment variable)")
+	rootCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", OutputText, "Output format: text, json or markdown")
+	rootCmd.PersistentFlags().StringVar(&colorFlag, "color", ColorModeAuto, "When to color text output: auto, always or never")
+	rootCmd.PersistentFlags().BoolVar(&noEmojiFlag, "no-emoji", false, "Do not show emojis, for terminals that cannot render them")
+	rootCmd.PersistentFlags().StringVar(&langFlag, "lang", "", "Language of the messages: en, fr or pt (overrides LANG)")
+	rootCmd.PersistentFlags().BoolVar(&animateFlag, "animate", false, "Reveal random picks with a spinning wheel of names (terminals only)")
+	rootCmd.PersistentFlags().DurationVar(&animateFor, "animate-duration", defaultAnimateDuration, "How long the reveal of --animate spins")
+	rootCmd.PersistentFlags().BoolVar(&announceFlag, "announce", false, "Announce picks with text-to-speech, or the terminal bell if unavailable")
+	rootCmd.PersistentFlags().BoolVar(&accessibleFlag, "accessible", false, "Screen-reader friendly mode: plain sentences without colors, emojis nor animations, and line-by-line input")
+	rootCmd.PersistentFlags().BoolVar(&noPreviewFlag, "no-preview", false, "Do not show who is on deck after each pick")
+	rootCmd.Flags().StringArrayVar(&guestFlags, "guest", nil, "Add a guest to the current round only (can be repeated)")
+
+	// Same commands as in the interactive mode, for one-shot use (e.g. in scripts)
+	var oneShotSession *session
+	rootCmd.AddCommand(newSessionCommands(func() *session {
+		oneShotSession = loadSession(getTeamFile(teamFileFlag))
+		return oneShotSession
+	})...)
+	// Wait for notifications of the command before exiting
+	rootCmd.PersistentPostRun = func(cmd *cobra.Command, args []string) {
+		if oneShotSession != nil {
+			oneShotSession.close()
+		}
+	}
 }
 
-func runApp(cmd *cobra.Command, args []string) {
-	teamFile := getTeamFile(teamFileFlag)
+// Number of upcoming speakers shown "on deck" after each pick
+const onDeckCount = 2
+
+// Everything the interactive commands need for the current run: the picker,
+// and how to present it
+type session struct {
+	*picker.Picker
+	render  Renderer
+	lang    *language
+	preview bool
+	// Where prompts and other interactive text go, so that machine-readable
+	// output on stdout stays clean
+	console io.Writer
+	// Reveal of random picks, if animated
+	reveal *reveal
+	// Announcement of picks, if enabled
+	announcements *announcements
+	// Round of this run and last speaker picked in it, for the messages
+	round      int
+	previous   string
+	previousAt time.Time
+	// Run when the session is closed
+	closers []func()
+}
+
+// Load the team and set up the session, exiting with a helpful message if
+// there is nobody to pick from
+func loadSession(teamFile string) *session {
+	lang, err := selectLanguage(langFlag)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	teamMembers := loadTeamOrExit(teamFile, lang)
+	cfg := loadConfigOrExit()
+	render, err := newRenderer(os.Stdout, renderOptions{
+		output:     outputFlag,
+		color:      colorFlag,
+		noEmoji:    noEmojiFlag,
+		theme:      cfg.theme,
+		messages:   cfg.Messages,
+		lang:       lang,
+		accessible: accessibleFlag,
+	})
+	if err != nil {
+		fmt.Println(lang.T("error", err))
+		os.Exit(1)
+	}
+
+	s := &session{
+		Picker:  picker.New(teamMembers, getStateFile(), getHistoryFile()),
+		render:  render,
+		lang:    lang,
+		preview: !noPreviewFlag,
+		console: os.Stdout,
+	}
+	if outputFlag != OutputText {
+		s.console = os.Stderr
+	}
+	// Only text output in a terminal can be redrawn in place
+	if animateFlag && !accessibleFlag && outputFlag == OutputText && term.IsTerminal(int(os.Stdout.Fd())) {
+		if animateFor <= 0 {
+			fmt.Println(lang.T("error", fmt.Errorf("invalid --animate-duration %s", animateFor)))
+			os.Exit(1)
+		}
+		s.reveal = newReveal(os.Stdout, animateFor, !noEmojiFlag)
+	}
+	if announceFlag || cfg.Announce != nil {
+		var announceCfg announceConfig
+		if cfg.Announce != nil {
+			announceCfg = *cfg.Announce
+		}
+		if err := s.announcePicks(announceCfg); err != nil {
+			fmt.Println(lang.T("error", err))
+			os.Exit(1)
+		}
+	}
+	s.notifyWebhooks(cfg, "")
+	s.runHooks(cfg, "")
+	return s
+}
+
+// Load the team, exiting with a helpful message if there is nobody to pick from
+func loadTeamOrExit(teamFile string, lang *language) []string {
 	teamMembers, err := loadTeamMembers(teamFile)
 	if err != nil {
-		fmt.Printf("Error loading team members: %v\n", err)
+		fmt.Println(lang.T("error.loadTeam", err))
 		if teamFile != "-" {
-			fmt.Printf("Please create a '%s' file with one team member name per line.\n", teamFile)
+			fmt.Println(lang.T("hint.teamFile", teamFile))
 		} else {
-			fmt.Printf("Please provide team member names via stdin (one per line).\n")
+			fmt.Println(lang.T("hint.stdin"))
 		}
 		os.Exit(1)
 	}
 
 	if len(teamMembers) == 0 {
 		if teamFile != "-" {
-			fmt.Printf("No team members found in '%s'. Please add team member names (one per line).\n", teamFile)
+			fmt.Println(lang.T("error.emptyTeamFile", teamFile))
 		} else {
-			fmt.Printf("No team members found in stdin. Please provide team member names (one per line).\n")
+			fmt.Println(lang.T("error.emptyStdin"))
 		}
 		os.Exit(1)
 	}
+	return teamMembers
+}
 
-	stateFile := getStateFile()
+func runApp(cmd *cobra.Command, args []string) {
+	teamFile := getTeamFile(teamFileFlag)
+	s := loadSession(teamFile)
+	defer s.close()
+	teamMembers := s.TeamMembers()
 
 	// Print welcome message and instructions
-	fmt.Println("=== Daily Scrum Picker ===")
-	if teamFile == "-" {
-		fmt.Printf("Team source: stdin (%d members)\n", len(teamMembers))
-	} else {
-		fmt.Printf("Team file: %s (%d members)\n", teamFile, len(teamMembers))
-	}
-	fmt.Printf("State file: %s\n", stateFile)
-	fmt.Println("\nCommands:")
-	fmt.Println("  p - Pick next person")
-	fmt.Println("  r - Reset and start over")
-	fmt.Println("  s - Show current status")
-	fmt.Println("  h - Show this help")
-	fmt.Println("  q - Quit")
-
-	// Check if we can use raw mode, otherwise fall back to buffered
-	if term.IsTerminal(int(os.Stdin.Fd())) {
-		fmt.Println("\nPress any key (no Enter needed):")
-		runRawMode(teamMembers, stateFile)
+	s.render.Welcome(welcomeData{
+		TeamFile:    teamFile,
+		Stdin:       teamFile == "-",
+		TeamMembers: len(teamMembers),
+		StateFile:   s.StateFile(),
+		HistoryFile: s.HistoryFile(),
+	})
+	_, _ = fmt.Fprintln(s.console, "\n"+s.lang.T("commands"))
+	for _, k := range helpKeys {
+		_, _ = fmt.Fprintf(s.console, "  %s - %s\n", k.key, s.lang.T(k.description))
+	}
+
+	if len(guestFlags) > 0 {
+		_, _ = fmt.Fprintln(s.console)
+		for _, guest := range guestFlags {
+			addGuest(s, guest)
+		}
+	}
+
+	// Check if we can use raw mode, otherwise fall back to buffered. Raw
+	// mode echoes keys itself, which screen readers do not follow.
+	if !accessibleFlag && term.IsTerminal(int(os.Stdin.Fd())) {
+		_, _ = fmt.Fprintln(s.console, "\n"+s.lang.T("pressAnyKey"))
+		runRawMode(s)
 	} else {
-		fmt.Println("\nType commands and press Enter:")
-		runBufferedMode(teamMembers, stateFile)
+		_, _ = fmt.Fprintln(s.console, "\n"+s.lang.T("typeCommands"))
+		runBufferedMode(s)
+	}
+
+	// The meeting is over: guests who did not get a turn are not carried over
+	if err := s.EndMeeting(); err != nil {
+		s.tell("error.endMeeting", err)
 	}
 }
 
@@ -130,23 +240,23 @@ func main() {
 	}
 }
 
-func runRawMode(teamMembers []string, stateFile string) {
+func runRawMode(s *session) {
 	// Set terminal to raw mode
 	oldState, err := term.MakeRaw(int(os.Stdin.Fd()))
 	if err != nil {
-		fmt.Println("Falling back to buffered mode...")
-		runBufferedMode(teamMembers, stateFile)
+		s.tell("fallbackBuffered")
+		runBufferedMode(s)
 		return
 	}
 	defer func() {
 		if err := term.Restore(int(os.Stdin.Fd()), oldState); err != nil {
-			fmt.Printf("Error restoring terminal: %v\n", err)
+			s.tell("error.restoreTerminal", err)
 		}
 	}()
 
 	for {
 		// Print prompt and flush output
-		fmt.Print("> ")
+		_, _ = fmt.Fprint(s.console, "> ")
 
 		// Read single character
 		buf := make([]byte, 1)
@@ -161,10 +271,10 @@ func runRawMode(teamMembers []string, stateFile string) {
 		if char == 3 {
 			// Restore terminal before exiting
 			if err := term.Restore(int(os.Stdin.Fd()), oldState); err != nil {
-				fmt.Printf("Error restoring terminal: %v\n", err)
+				s.tell("error.restoreTerminal", err)
 			}
-			fmt.Print("\n")
-			fmt.Println(goodbyeMessage)
+			_, _ = fmt.Fprint(s.console, "\n")
+			s.tell("goodbye")
 			return
 		}
 
@@ -177,237 +287,290 @@ func runRawMode(teamMembers []string, stateFile string) {
 
 		// Restore terminal temporarily for clean output
 		if err := term.Restore(int(os.Stdin.Fd()), oldState); err != nil {
-			fmt.Printf("Error restoring terminal: %v\n", err)
+			s.tell("error.restoreTerminal", err)
 		}
 
 		// Clear current line and show command
-		fmt.Printf("\r> %s\n", input)
+		_, _ = fmt.Fprintf(s.console, "\r> %s\n", input)
 
 		// Handle the command
 		switch input {
 		case "p":
-			pickNextPerson(teamMembers, stateFile)
+			pickNextPerson(s)
+		case "m":
+			promptManualPickRaw(s)
+		case "g":
+			promptGuestRaw(s)
+		case "u":
+			undoLast(s)
 		case "r":
-			resetState(teamMembers, stateFile)
+			resetState(s)
 		case "s":
-			showStatus(teamMembers, stateFile)
+			showStatus(s)
 		case "h":
-			showHelp()
+			s.render.Help()
 		case "q":
-			fmt.Println(goodbyeMessage)
+			s.tell("goodbye")
 			return
 		default:
-			fmt.Printf("Unknown command: '%s'. Press 'h' for help.\n", input)
+			s.tell("unknownKey", input)
 		}
 
-		fmt.Println() // Add separation
+		_, _ = fmt.Fprintln(s.console) // Add separation
 
 		// Re-enter raw mode for next command
 		oldState, err = term.MakeRaw(int(os.Stdin.Fd()))
 		if err != nil {
-			fmt.Println("Error re-entering raw mode, exiting...")
+			s.tell("error.reenterRawMode")
 			return
 		}
 	}
 }
 
+// Command names offered for Tab completion in buffered mode
+var bufferedCommands = []string{
+	"pick", "manual", "skip", "absent", "guest", "add guest", "undo", "reset", "status", "help", "quit", "exit",
+}
+
 // Fallback function for systems where raw mode doesn't work
-func runBufferedMode(teamMembers []string, stateFile string) {
-	scanner := bufio.NewScanner(os.Stdin)
+func runBufferedMode(s *session) {
+	editor := &lineEditor{in: os.Stdin, out: s.console}
+	editor.complete = func(line string) []string {
+		return completeCommandLine(line, bufferedCommands, append(s.TeamMembers(), s.Guests()...))
+	}
+
 	for {
-		fmt.Print("> ")
-		if !scanner.Scan() {
+		line, err := editor.readLine("> ")
+		if errors.Is(err, errPromptCancelled) {
+			continue
+		}
+		if err != nil {
 			break // EOF or error
 		}
-
-		input := strings.TrimSpace(strings.ToLower(scanner.Text()))
-
-		switch input {
-		case "p", "pick":
-			pickNextPerson(teamMembers, stateFile)
-		case "r", "reset":
-			resetState(teamMembers, stateFile)
-		case "s", "status":
-			showStatus(teamMembers, stateFile)
+		editor.addHistory(line)
+
+		input := strings.TrimSpace(line)
+
+		switch strings.ToLower(input) {
+		case "m", "manual":
+			remaining := currentRound(s)
+			s.render.Candidates(remaining, s.Guests())
+			name, err := editor.ask(s.lang.T("prompt.manualPick"), remaining)
+			if errors.Is(err, errPromptCancelled) {
+				s.tell("manualPickCancelled")
+				continue
+			}
+			if err != nil {
+				return
+			}
+			manualPick(s, name)
+		case "g", "guest":
+			name, err := editor.ask(s.lang.T("prompt.guest"), nil)
+			if errors.Is(err, errPromptCancelled) {
+				s.tell("guestCancelled")
+				continue
+			}
+			if err != nil {
+				return
+			}
+			addGuest(s, name)
 		case "h", "help":
-			showHelp()
+			s.render.Help()
 		case "q", "quit", "exit":
-			fmt.Println(goodbyeMessage)
+			s.tell("goodbye")
 			return
 		case "":
 			// Empty input, just continue
 			continue
 		default:
-			fmt.Printf("Unknown command: '%s'. Type 'h' for help.\n", input)
+			args, err := splitCommandLine(input)
+			if err != nil {
+				s.tell("invalidCommand", err)
+				continue
+			}
+			if err := runSessionCommand(s, args); errors.Is(err, errUnknownCommand) {
+				s.tell("unknownCommand", args[0])
+			} else if err != nil {
+				s.tell("error", err)
+			}
 		}
 	}
 }
 
-func pickNextPerson(teamMembers []string, stateFile string) {
-	remaining := loadRemaining(teamMembers, stateFile)
-
-	// If no one left, reset
-	if len(remaining) == 0 {
-		fmt.Println("Everyone has already had a turn. Resetting list...")
-		remaining = shuffle(copySlice(teamMembers))
+func pickNextPerson(s *session) {
+	result, err := s.Pick("")
+	if err != nil {
+		s.render.Error("pick", err)
+		return
 	}
+	if s.reveal != nil {
+		s.reveal.spin(append(slices.Clone(result.Remaining), result.Name), result.Name)
+	}
+	s.showPick(result)
+}
 
-	// Pick the first person (since shuffled)
-	picked := remaining[0]
-	remaining = remaining[1:]
-
-	// Save updated list
-	saveRemaining(remaining, stateFile)
-
-	// Display the picked person with prominent formatting - using colors that work on both backgrounds
-	fmt.Printf("🎯 Next is... %s%s%s%s\n",
-		Bold, BoldBlue, picked, ColorReset)
-
-	// Show remaining count with color that works universally
-	if len(remaining) > 0 {
-		fmt.Printf("%s(%d people remaining in this round)%s\n",
-			BrightRed, len(remaining), ColorReset)
-	} else {
-		fmt.Printf("%s(That was the last person in this round)%s\n",
-			BoldGreen, ColorReset)
+// Pick a specific remaining member chosen by the facilitator (e.g. someone who
+// has to leave early), recording it in history as a manual override
+func manualPick(s *session, input string) {
+	if strings.TrimSpace(input) == "" {
+		s.render.Error("pick", picker.ErrNoName)
+		return
 	}
+	result, err := s.Pick(input)
+	if err != nil {
+		s.render.Error("pick", err)
+		return
+	}
+	s.showPick(result)
+}
+
+// Print a translated line of interactive text
+func (s *session) tell(id string, args ...any) {
+	_, _ = fmt.Fprintln(s.console, s.lang.T(id, args...))
 }
 
-func resetState(teamMembers []string, stateFile string) {
-	// Remove state file to reset
-	if err := os.Remove(stateFile); err != nil && !os.IsNotExist(err) {
-		fmt.Printf("Warning: failed to remove state file: %v\n", err)
+func (s *session) showPick(result picker.PickResult) {
+	data := s.pickData(result)
+	s.render.Pick(data)
+	if s.announcements != nil {
+		s.announcements.announce(data)
 	}
-	fmt.Printf("%s✅ State reset! All %d team members are available for selection.%s\n",
-		BoldGreen, len(teamMembers), ColorReset)
 }
 
-func showStatus(teamMembers []string, stateFile string) {
-	remaining := loadRemaining(teamMembers, stateFile)
+// Let the facilitator choose the next person on a raw terminal, with Tab completion
+func promptManualPickRaw(s *session) {
+	remaining := currentRound(s)
+	s.render.Candidates(remaining, s.Guests())
+	s.tell("prompt.hint")
 
-	fmt.Printf("%s📊 Status:%s\n", BoldBlue, ColorReset)
-	fmt.Printf("  Total team members: %s%d%s\n", DarkBlue, len(teamMembers), ColorReset)
-	fmt.Printf("  Remaining this round: %s%d%s\n", BrightRed, len(remaining), ColorReset)
+	oldState, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		s.tell("error.rawMode", err)
+		return
+	}
+	input, err := readLineRaw(os.Stdin, s.console, s.lang.T("prompt.manualPick"), remaining)
+	if err := term.Restore(int(os.Stdin.Fd()), oldState); err != nil {
+		s.tell("error.restoreTerminal", err)
+	}
 
-	if len(remaining) > 0 {
-		fmt.Printf("  Still to pick: %s%s%s\n",
-			DarkGreen, strings.Join(remaining, ", "), ColorReset)
-	} else {
-		fmt.Printf("  %sEveryone has been picked this round%s\n",
-			BoldGreen, ColorReset)
+	if errors.Is(err, errPromptCancelled) {
+		s.tell("manualPickCancelled")
+		return
+	}
+	if err != nil {
+		s.tell("error.readInput", err)
+		return
 	}
+	manualPick(s, input)
 }
 
-func showHelp() {
-	fmt.Printf("\n%s📋 Available commands:%s\n", BoldBlue, ColorReset)
-	fmt.Printf("  %sp%s, pick   - Pick the next person for daily scrum\n", BoldGreen, ColorReset)
-	fmt.Printf("  %sr%s, reset  - Reset state and start over with all team members\n", BrightRed, ColorReset)
-	fmt.Printf("  %ss%s, status - Show current status and remaining team members\n", BoldBlue, ColorReset)
-	fmt.Printf("  %sh%s, help   - Show this help message\n", BoldPurple, ColorReset)
-	fmt.Printf("  %sq%s, quit   - Exit the program\n", BoldRed, ColorReset)
-	fmt.Println()
+func skipPerson(s *session, input string) {
+	result, err := s.Skip(input)
+	if err != nil {
+		s.render.Error("skip", err)
+		return
+	}
+	s.render.Skip(result, s.onDeck(result.Remaining))
 }
 
-// Load team members from file or stdin
-func loadTeamMembers(teamFile string) ([]string, error) {
-	var scanner *bufio.Scanner
+func markAbsent(s *session, input string) {
+	result, err := s.MarkAbsent(input)
+	if err != nil {
+		s.render.Error("mark absent", err)
+		return
+	}
+	s.render.Absent(result)
+}
 
-	if teamFile == "-" {
-		// Read from stdin
-		scanner = bufio.NewScanner(os.Stdin)
-	} else {
-		// Read from file
-		file, err := os.Open(teamFile)
-		if err != nil {
-			return nil, err
-		}
-		defer func() {
-			if err := file.Close(); err != nil {
-				fmt.Printf("Warning: failed to close file: %v\n", err)
-			}
-		}()
-		scanner = bufio.NewScanner(file)
+func undoLast(s *session) {
+	result, err := s.Undo()
+	if err != nil {
+		s.render.Error("undo", err)
+		return
 	}
+	s.render.Undo(result, s.onDeck(result.Remaining))
+}
 
-	var members []string
-	for scanner.Scan() {
-		name := strings.TrimSpace(scanner.Text())
-		if name != "" && !strings.HasPrefix(name, "#") {
-			members = append(members, name)
-		}
+// Context of a pick, for the messages. Rounds and speaking times are only
+// known for the picks of this run.
+func (s *session) pickData(result picker.PickResult) pickData {
+	if s.round == 0 || result.NewRound {
+		s.round++
+		s.previous = ""
 	}
+	data := pickData{
+		Name:      result.Name,
+		Action:    result.Action,
+		Manual:    result.Action == picker.ActionManualPick,
+		NewRound:  result.NewRound,
+		Remaining: result.Remaining,
+		OnDeck:    s.onDeck(result.Remaining),
+		Round:     s.round,
+		Position:  result.Position,
+		Previous:  s.previous,
+	}
+	now := time.Now()
+	if s.previous != "" {
+		data.Elapsed = now.Sub(s.previousAt).Round(time.Second)
+	}
+	s.previous, s.previousAt = result.Name, now
+	return data
+}
 
-	if err := scanner.Err(); err != nil {
-		return nil, err
+// Next speakers to give a heads-up to, unless previews are disabled
+func (s *session) onDeck(remaining []string) []string {
+	if !s.preview {
+		return nil
 	}
+	return remaining[:min(onDeckCount, len(remaining))]
+}
 
-	return members, nil
+func resetState(s *session) {
+	if err := s.Reset(); err != nil {
+		s.render.Error("reset", err)
+		return
+	}
+	// The next pick starts a new round
+	if s.round > 0 {
+		s.round++
+	}
+	s.previous = ""
+	s.render.Reset(len(s.TeamMembers()))
 }
 
-// Load remaining names from file; if not exists, return full list shuffled
-func loadRemaining(teamMembers []string, stateFile string) []string {
-	file, err := os.Open(stateFile)
+func showStatus(s *session) {
+	status, err := s.Status()
 	if err != nil {
-		// File not found → start fresh
-		return shuffle(copySlice(teamMembers))
+		s.render.Error("show status", err)
+		return
 	}
-	defer func() {
-		if err := file.Close(); err != nil {
-			fmt.Printf("Warning: failed to close file: %v\n", err)
-		}
-	}()
+	s.render.Status(status)
+}
 
-	var names []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		name := strings.TrimSpace(scanner.Text())
-		if name != "" {
-			names = append(names, name)
-		}
+// Remaining members of the current round, as listed for a manual pick. Errors
+// are reported, leaving the list empty.
+func currentRound(s *session) []string {
+	remaining, _, err := s.CurrentRound()
+	if err != nil {
+		s.render.Error("load the round", err)
 	}
-	return names
+	return remaining
 }
 
-// Save remaining names to file
-func saveRemaining(names []string, stateFile string) {
-	if len(names) == 0 {
-		// Remove file to reset
-		if err := os.Remove(stateFile); err != nil && !os.IsNotExist(err) {
-			fmt.Printf("Warning: failed to remove state file: %v\n", err)
-		}
-		return
+// Load team members from file or stdin
+func loadTeamMembers(teamFile string) ([]string, error) {
+	if teamFile == "-" {
+		return picker.ParseTeam(os.Stdin)
 	}
 
-	file, err := os.Create(stateFile)
+	file, err := os.Open(teamFile)
 	if err != nil {
-		fmt.Printf("Error writing state file: %v\n", err)
-		os.Exit(1)
+		return nil, err
 	}
 	defer func() {
 		if err := file.Close(); err != nil {
 			fmt.Printf("Warning: failed to close file: %v\n", err)
 		}
 	}()
-
-	for _, name := range names {
-		if _, err := file.WriteString(name + "\n"); err != nil {
-			fmt.Printf("Error writing to state file: %v\n", err)
-			return
-		}
-	}
-}
-
-// Shuffle a slice
-func shuffle(slice []string) []string {
-	rand.Shuffle(len(slice), func(i, j int) {
-		slice[i], slice[j] = slice[j], slice[i]
-	})
-	return slice
-}
-
-// Helper to copy slice
-func copySlice(slice []string) []string {
-	newSlice := make([]string, len(slice))
-	copy(newSlice, slice)
-	return newSlice
+	return picker.ParseTeam(file)
 }
@@ -3,8 +3,11 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/rm3l/daily-scrum-picker/picker"
 )
 
 func TestGetTeamFile(t *testing.T) {
@@ -315,6 +318,74 @@ func TestGetStateFile(t *testing.T) {
 	}
 }
 
+// Session backed by state and history files in a temporary directory
+func newTestSession(t *testing.T, teamMembers ...string) *session {
+	t.Helper()
+	dir := t.TempDir()
+	render, err := newRenderer(os.Stdout, renderOptions{color: ColorModeNever})
+	if err != nil {
+		t.Fatalf("newRenderer failed: %v", err)
+	}
+	return &session{
+		Picker: picker.New(teamMembers, filepath.Join(dir, "state.txt"), filepath.Join(dir, "history.txt")),
+		render: render,
+		lang:   english,
+	}
+}
+
+// Start the session's round with the given remaining members
+func setTestRound(t *testing.T, s *session, names ...string) {
+	t.Helper()
+	if err := os.WriteFile(s.StateFile(), []byte(strings.Join(names, "\n")+"\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write the round: %v", err)
+	}
+}
+
+// Remaining members of the session's round
+func testRemaining(t *testing.T, s *session) []string {
+	t.Helper()
+	status, err := s.Status()
+	if err != nil {
+		t.Fatalf("Failed to get the status: %v", err)
+	}
+	return status.Remaining
+}
+
+func TestManualPick(t *testing.T) {
+	s := newTestSession(t, "Alice", "Bob", "Charlie")
+	setTestRound(t, s, "Alice", "Bob", "Charlie")
+	manualPick(s, "2")
+
+	remaining := testRemaining(t, s)
+	expected := []string{"Alice", "Charlie"}
+	if strings.Join(remaining, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected remaining %v, got %v", expected, remaining)
+	}
+
+	entries, err := s.History()
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name != "Bob" || entries[0].Action != picker.ActionManualPick {
+		t.Errorf("Expected a manual history entry for Bob, got %+v", entries)
+	}
+}
+
+func TestManualPick_UnknownNameLeavesStateUntouched(t *testing.T) {
+	s := newTestSession(t, "Alice", "Bob")
+	setTestRound(t, s, "Alice", "Bob")
+
+	manualPick(s, "Zoe")
+
+	remaining := testRemaining(t, s)
+	if len(remaining) != 2 {
+		t.Errorf("Expected state to be untouched, got %v", remaining)
+	}
+	if _, err := os.Stat(s.HistoryFile()); !os.IsNotExist(err) {
+		t.Errorf("Expected no history to be recorded, got err=%v", err)
+	}
+}
+
 // Benchmark tests
 func BenchmarkGetTeamFile(b *testing.B) {
 	b.Setenv("TEAM_FILE", "bench-team.txt")
@@ -0,0 +1,60 @@
+package picker
+
+import "time"
+
+// Types of events emitted when the round changes
+const (
+	EventPick   = "pick"
+	EventSkip   = "skip"
+	EventAbsent = "absent"
+	EventUndo   = "undo"
+	EventReset  = "reset"
+	// Everyone in the round has had a turn (or is away)
+	EventRoundComplete = "roundComplete"
+	// The meeting is over, e.g. the interactive session was quit
+	EventMeetingEnd = "meetingEnd"
+)
+
+// Event describes a change to the round, for anyone following along
+// (e.g. live clients of a server)
+type Event struct {
+	Type string    `json:"type"`
+	Time time.Time `json:"time"`
+	// Member the event is about, if any
+	Name string `json:"name,omitempty"`
+	// History action behind the event, e.g. random or manual for a pick
+	Action    string   `json:"action,omitempty"`
+	Remaining []string `json:"remaining"`
+	// A new round was started for this pick
+	NewRound bool `json:"newRound,omitempty"`
+}
+
+// Subscribe registers a function called synchronously after every change to
+// the round
+func (p *Picker) Subscribe(listener func(Event)) {
+	p.listeners = append(p.listeners, listener)
+}
+
+// EndMeeting notifies listeners that the meeting is over. Guests who did not
+// get a turn are dropped, so that they do not leak into the next meeting.
+func (p *Picker) EndMeeting() error {
+	if err := p.DropGuests(); err != nil {
+		return err
+	}
+	remaining, err := loadRemaining(p.teamMembers, p.stateFile)
+	if err != nil {
+		return err
+	}
+	p.emit(Event{Type: EventMeetingEnd, Remaining: remaining})
+	return nil
+}
+
+func (p *Picker) emit(event Event) {
+	event.Time = time.Now()
+	if event.Remaining == nil {
+		event.Remaining = []string{}
+	}
+	for _, listener := range p.listeners {
+		listener(event)
+	}
+}
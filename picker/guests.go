@@ -0,0 +1,87 @@
+package picker
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// Errors adding a guest
+var (
+	ErrGuestIsMember  = errors.New("already a team member")
+	ErrAlreadyInRound = errors.New("already in this round")
+)
+
+// AddGuest adds a temporary participant to the current round. The team is
+// left untouched and the guest is dropped once the round or the meeting ends.
+func (p *Picker) AddGuest(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrNoName
+	}
+
+	if containsFold(p.teamMembers, name) {
+		return ErrGuestIsMember
+	}
+
+	remaining, _, err := p.CurrentRound()
+	if err != nil {
+		return err
+	}
+	if containsFold(remaining, name) {
+		return ErrAlreadyInRound
+	}
+
+	// Insert at a random position so guests are not always first or last
+	remaining = slices.Insert(remaining, rand.Intn(len(remaining)+1), name)
+	if err := saveRemaining(remaining, p.stateFile); err != nil {
+		return err
+	}
+	p.guests = append(p.guests, name)
+	return nil
+}
+
+// DropGuests removes guests who did not get a turn, so they do not leak into
+// the next meeting
+func (p *Picker) DropGuests() error {
+	remaining, err := loadRemaining(p.teamMembers, p.stateFile)
+	if err != nil {
+		return err
+	}
+	p.trackGuests(remaining)
+	if len(p.guests) == 0 {
+		return nil
+	}
+	kept := slices.DeleteFunc(slices.Clone(remaining), func(name string) bool {
+		return slices.Contains(p.guests, name)
+	})
+	if len(kept) != len(remaining) {
+		if err := saveRemaining(kept, p.stateFile); err != nil {
+			return err
+		}
+	}
+	p.guests = nil
+	return nil
+}
+
+// Track anyone in the round who is not on the team as a guest, e.g. a guest
+// whose pick was undone after their round ended, or one added by another run
+func (p *Picker) trackGuests(remaining []string) {
+	for _, name := range remaining {
+		if !slices.Contains(p.teamMembers, name) && !slices.Contains(p.guests, name) {
+			p.guests = append(p.guests, name)
+		}
+	}
+}
+
+// IsGuest reports whether name is a guest of the current round
+func (p *Picker) IsGuest(name string) bool {
+	return slices.Contains(p.guests, name)
+}
+
+func containsFold(names []string, name string) bool {
+	return slices.ContainsFunc(names, func(n string) bool {
+		return strings.EqualFold(n, name)
+	})
+}
@@ -0,0 +1,106 @@
+package picker
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Actions recorded in the history file
+const (
+	ActionRandomPick = "random"
+	ActionManualPick = "manual"
+	ActionSkip       = "skip"
+	ActionAbsent     = "absent"
+)
+
+// HistoryEntry is a single line of the history file
+type HistoryEntry struct {
+	Time   time.Time `json:"time"`
+	Action string    `json:"action"`
+	Name   string    `json:"name"`
+}
+
+// Append an entry to the history file (tab-separated: time, action, name)
+func appendHistory(historyFile string, entry HistoryEntry) (err error) {
+	file, err := os.OpenFile(historyFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+
+	_, err = file.WriteString(formatHistoryEntry(entry))
+	return err
+}
+
+func formatHistoryEntry(entry HistoryEntry) string {
+	return fmt.Sprintf("%s\t%s\t%s\n", entry.Time.Format(time.RFC3339), entry.Action, entry.Name)
+}
+
+// Load all history entries; a missing file means no history yet
+func loadHistory(historyFile string) (entries []HistoryEntry, err error) {
+	file, err := os.Open(historyFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.SplitN(strings.TrimSpace(scanner.Text()), "\t", 3)
+		if len(fields) != 3 {
+			// Skip malformed lines rather than failing the whole history
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, fields[0])
+		if err != nil {
+			continue
+		}
+		entries = append(entries, HistoryEntry{Time: t, Action: fields[1], Name: fields[2]})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// Remove and return the most recent entry, e.g. to undo it
+func removeLastHistoryEntry(historyFile string) (HistoryEntry, error) {
+	entries, err := loadHistory(historyFile)
+	if err != nil {
+		return HistoryEntry{}, err
+	}
+	if len(entries) == 0 {
+		return HistoryEntry{}, ErrNothingToUndo
+	}
+
+	last := entries[len(entries)-1]
+	var content strings.Builder
+	for _, entry := range entries[:len(entries)-1] {
+		content.WriteString(formatHistoryEntry(entry))
+	}
+	if err := os.WriteFile(historyFile, []byte(content.String()), 0o644); err != nil {
+		return HistoryEntry{}, err
+	}
+	return last, nil
+}
+
+func recordHistory(historyFile, name, action string) error {
+	entry := HistoryEntry{Time: time.Now(), Action: action, Name: name}
+	if err := appendHistory(historyFile, entry); err != nil {
+		return fmt.Errorf("failed to record history: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package picker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppendAndLoadHistory(t *testing.T) {
+	historyFile := filepath.Join(t.TempDir(), "history.txt")
+
+	now := time.Now().Truncate(time.Second)
+	entries := []HistoryEntry{
+		{Time: now, Action: ActionRandomPick, Name: "Alice"},
+		{Time: now.Add(time.Minute), Action: ActionManualPick, Name: "Bob Smith"},
+	}
+	for _, entry := range entries {
+		if err := appendHistory(historyFile, entry); err != nil {
+			t.Fatalf("appendHistory failed: %v", err)
+		}
+	}
+
+	loaded, err := loadHistory(historyFile)
+	if err != nil {
+		t.Fatalf("loadHistory failed: %v", err)
+	}
+	if len(loaded) != len(entries) {
+		t.Fatalf("Expected %d entries, got %d", len(entries), len(loaded))
+	}
+	for i, entry := range loaded {
+		if !entry.Time.Equal(entries[i].Time) || entry.Action != entries[i].Action || entry.Name != entries[i].Name {
+			t.Errorf("Entry %d = %+v; want %+v", i, entry, entries[i])
+		}
+	}
+}
+
+func TestLoadHistory_MissingFileAndMalformedLines(t *testing.T) {
+	dir := t.TempDir()
+
+	entries, err := loadHistory(filepath.Join(dir, "missing.txt"))
+	if err != nil || len(entries) != 0 {
+		t.Errorf("Expected no entries and no error for missing file, got %v, %v", entries, err)
+	}
+
+	historyFile := filepath.Join(dir, "history.txt")
+	content := "garbage\nnot-a-time\trandom\tAlice\n2025-07-24T09:00:00Z\tmanual\tBob\n"
+	if err := os.WriteFile(historyFile, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write history file: %v", err)
+	}
+	entries, err = loadHistory(historyFile)
+	if err != nil {
+		t.Fatalf("loadHistory failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name != "Bob" || entries[0].Action != ActionManualPick {
+		t.Errorf("Expected only the valid entry for Bob, got %+v", entries)
+	}
+}
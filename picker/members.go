@@ -0,0 +1,103 @@
+package picker
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Errors resolving a member, so callers (e.g. an HTTP API) can tell them apart
+var (
+	ErrNoName          = errors.New("no name given")
+	ErrNoSuchMember    = errors.New("no such member")
+	ErrAmbiguousMember = errors.New("ambiguous member")
+)
+
+// MemberError tells why input does not resolve to a remaining member, with
+// the details needed to explain it (e.g. in another language)
+type MemberError struct {
+	// ErrNoSuchMember or ErrAmbiguousMember
+	Err   error
+	Input string
+	// Number typed out of the range of the remaining members
+	OutOfRange bool
+	Number     int
+	Remaining  int
+	// Members an ambiguous input could be
+	Matches []string
+}
+
+func (e *MemberError) Error() string {
+	switch {
+	case errors.Is(e.Err, ErrAmbiguousMember):
+		return fmt.Sprintf("%v: '%s' could be %s", e.Err, e.Input, strings.Join(e.Matches, ", "))
+	case e.OutOfRange:
+		return fmt.Sprintf("%v: no remaining member with number %d (expected 1-%d)", e.Err, e.Number, e.Remaining)
+	default:
+		return fmt.Sprintf("%v: nobody remaining matches '%s'", e.Err, e.Input)
+	}
+}
+
+func (e *MemberError) Unwrap() error {
+	return e.Err
+}
+
+// ParseTeam reads team members, one per line. Blank lines and lines starting
+// with # are ignored.
+func ParseTeam(r io.Reader) ([]string, error) {
+	var members []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		name := strings.TrimSpace(scanner.Text())
+		if name != "" && !strings.HasPrefix(name, "#") {
+			members = append(members, name)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// Resolve what the facilitator typed (a 1-based number from the status list,
+// a full name or an unambiguous prefix) to one of the remaining members
+func resolveMember(input string, remaining []string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", ErrNoName
+	}
+
+	if n, err := strconv.Atoi(input); err == nil {
+		if n < 1 || n > len(remaining) {
+			return "", &MemberError{Err: ErrNoSuchMember, Input: input, OutOfRange: true, Number: n, Remaining: len(remaining)}
+		}
+		return remaining[n-1], nil
+	}
+
+	for _, name := range remaining {
+		if strings.EqualFold(name, input) {
+			return name, nil
+		}
+	}
+
+	var matches []string
+	lowerInput := strings.ToLower(input)
+	for _, name := range remaining {
+		if strings.HasPrefix(strings.ToLower(name), lowerInput) {
+			matches = append(matches, name)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", &MemberError{Err: ErrNoSuchMember, Input: input}
+	case 1:
+		return matches[0], nil
+	default:
+		return "", &MemberError{Err: ErrAmbiguousMember, Input: input, Matches: matches}
+	}
+}
@@ -0,0 +1,87 @@
+package picker
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestResolveMember(t *testing.T) {
+	remaining := []string{"Alice", "Albert", "Bob", "Charlie"}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "number from status list", input: "3", expected: "Bob"},
+		{name: "exact name, case-insensitive", input: "charlie", expected: "Charlie"},
+		{name: "unique prefix", input: "alb", expected: "Albert"},
+		{name: "surrounding whitespace", input: "  bob \n", expected: "Bob"},
+		{name: "ambiguous prefix", input: "al", wantErr: true},
+		{name: "no match", input: "Zoe", wantErr: true},
+		{name: "number out of range", input: "5", wantErr: true},
+		{name: "zero", input: "0", wantErr: true},
+		{name: "empty input", input: "   ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := resolveMember(tt.input, remaining)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("resolveMember(%q) = %q; expected an error", tt.input, result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveMember(%q) failed: %v", tt.input, err)
+			}
+			if result != tt.expected {
+				t.Errorf("resolveMember(%q) = %q; want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolveMember_Errors(t *testing.T) {
+	remaining := []string{"Alice", "Albert", "Bob"}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "al", expected: "ambiguous member: 'al' could be Alice, Albert"},
+		{input: "Zoe", expected: "no such member: nobody remaining matches 'Zoe'"},
+		{input: "0", expected: "no such member: no remaining member with number 0 (expected 1-3)"},
+	}
+
+	for _, tt := range tests {
+		_, err := resolveMember(tt.input, remaining)
+		var memberErr *MemberError
+		if !errors.As(err, &memberErr) {
+			t.Fatalf("resolveMember(%q): expected a MemberError, got %v", tt.input, err)
+		}
+		if err.Error() != tt.expected {
+			t.Errorf("resolveMember(%q): expected %q, got %q", tt.input, tt.expected, err.Error())
+		}
+	}
+
+	_, err := resolveMember("al", remaining)
+	var memberErr *MemberError
+	if errors.As(err, &memberErr); !errors.Is(err, ErrAmbiguousMember) || !slices.Equal(memberErr.Matches, []string{"Alice", "Albert"}) {
+		t.Errorf("Expected the matches of an ambiguous input, got %v", err)
+	}
+}
+
+func TestParseTeam(t *testing.T) {
+	members, err := ParseTeam(strings.NewReader("# Team\n\n  Alice  \nBob Smith\n#Charlie\n"))
+	if err != nil {
+		t.Fatalf("ParseTeam failed: %v", err)
+	}
+	if strings.Join(members, ",") != "Alice,Bob Smith" {
+		t.Errorf("Expected [Alice Bob Smith], got %v", members)
+	}
+}
@@ -0,0 +1,292 @@
+// Package picker fairly selects the next person to speak during daily scrum
+// meetings: everyone gets a turn, in a shuffled order, before a new round
+// starts.
+//
+// The order of the current round and the history of picks are kept in files,
+// so that the rotation carries over between runs. A Picker is not safe for
+// concurrent use: callers serving several clients (e.g. over HTTP) serialize
+// their calls.
+package picker
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrNothingToUndo is returned by Undo when the history is empty
+	ErrNothingToUndo = errors.New("nothing to undo")
+	// ErrNoMembers is returned when a round cannot start as the team is empty
+	ErrNoMembers = errors.New("no team members")
+)
+
+// Picker runs the rotation of a team
+type Picker struct {
+	teamMembers []string
+	stateFile   string
+	historyFile string
+	// Guests added for this meeting only, dropped when the round or meeting ends
+	guests []string
+	// Notified of every change to the round, e.g. to broadcast it
+	listeners []func(Event)
+}
+
+// New returns a picker for the team, keeping the remaining members of the
+// current round in stateFile and past picks in historyFile
+func New(teamMembers []string, stateFile, historyFile string) *Picker {
+	return &Picker{
+		teamMembers: slices.Clone(teamMembers),
+		stateFile:   stateFile,
+		historyFile: historyFile,
+	}
+}
+
+// PickResult is the outcome of a pick
+type PickResult struct {
+	Name      string   `json:"name"`
+	Action    string   `json:"action"`
+	Remaining []string `json:"remaining"`
+	// A new round was started for this pick
+	NewRound bool `json:"newRound"`
+	// Turn of the pick in its round, counting guests and absences, from 1
+	Position int `json:"position"`
+}
+
+// MemberResult is the outcome of an operation on a single member (skip,
+// absence, undo)
+type MemberResult struct {
+	Name      string   `json:"name"`
+	Action    string   `json:"action"`
+	Remaining []string `json:"remaining"`
+}
+
+// Status is a snapshot of the current round
+type Status struct {
+	TeamMembers int      `json:"teamMembers"`
+	Remaining   []string `json:"remaining"`
+	Guests      []string `json:"guests,omitempty"`
+}
+
+// TeamMembers returns the members of the team, in the order they were given
+func (p *Picker) TeamMembers() []string {
+	return slices.Clone(p.teamMembers)
+}
+
+// Guests returns the guests of the current round
+func (p *Picker) Guests() []string {
+	return slices.Clone(p.guests)
+}
+
+// StateFile returns where the remaining members of the round are kept
+func (p *Picker) StateFile() string {
+	return p.stateFile
+}
+
+// HistoryFile returns where past picks, skips and absences are kept
+func (p *Picker) HistoryFile() string {
+	return p.historyFile
+}
+
+// CurrentRound returns the remaining members of the current round. A new
+// shuffled round is started and saved right away when there is none yet or
+// everyone has had a turn (in which case newRound is true), so that what is
+// shown (e.g. numbers in a status list) matches what the next call acts on.
+// It fails with ErrNoMembers when a new round is needed for an empty team.
+func (p *Picker) CurrentRound() (remaining []string, newRound bool, err error) {
+	_, err = os.Stat(p.stateFile)
+	started := err == nil
+	if started {
+		if remaining, err = loadRemaining(p.teamMembers, p.stateFile); err != nil {
+			return nil, false, err
+		}
+		if len(remaining) > 0 {
+			p.trackGuests(remaining)
+			return remaining, false, nil
+		}
+	}
+
+	if len(p.teamMembers) == 0 {
+		return nil, false, ErrNoMembers
+	}
+	// Guests only take part in the round they joined
+	p.guests = nil
+	remaining = shuffle(slices.Clone(p.teamMembers))
+	if err := saveRemaining(remaining, p.stateFile); err != nil {
+		return nil, false, err
+	}
+	return remaining, started, nil
+}
+
+// Remaining returns the remaining members of the current round without
+// starting a new one or saving anything: nobody when no round has been
+// started yet or everyone has had a turn.
+func (p *Picker) Remaining() ([]string, error) {
+	if _, err := os.Stat(p.stateFile); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	remaining, err := loadRemaining(p.teamMembers, p.stateFile)
+	if err != nil {
+		return nil, err
+	}
+	p.trackGuests(remaining)
+	return remaining, nil
+}
+
+// Pick picks the next person in the shuffled order, or the remaining member
+// matching input (a name, prefix or number) as a manual override
+func (p *Picker) Pick(input string) (PickResult, error) {
+	remaining, newRound, err := p.CurrentRound()
+	if err != nil {
+		return PickResult{}, err
+	}
+
+	picked, action := remaining[0], ActionRandomPick
+	if strings.TrimSpace(input) != "" {
+		if picked, err = resolveMember(input, remaining); err != nil {
+			return PickResult{}, err
+		}
+		action = ActionManualPick
+	}
+
+	remaining = removeName(remaining, picked)
+	if err := saveRemaining(remaining, p.stateFile); err != nil {
+		return PickResult{}, err
+	}
+	if err := recordHistory(p.historyFile, picked, action); err != nil {
+		return PickResult{}, err
+	}
+
+	// Guests still count, as they are dropped once the round is over
+	position := max(1, len(p.teamMembers)+len(p.guests)-len(remaining))
+	if len(remaining) == 0 {
+		p.guests = nil
+	}
+
+	p.emit(Event{Type: EventPick, Name: picked, Action: action, Remaining: remaining, NewRound: newRound})
+	if len(remaining) == 0 {
+		p.emit(Event{Type: EventRoundComplete})
+	}
+	return PickResult{Name: picked, Action: action, Remaining: remaining, NewRound: newRound, Position: position}, nil
+}
+
+// Skip moves someone (the next in line by default) to the end of the round,
+// e.g. when they have not joined the call yet
+func (p *Picker) Skip(input string) (MemberResult, error) {
+	remaining, _, err := p.CurrentRound()
+	if err != nil {
+		return MemberResult{}, err
+	}
+
+	skipped := remaining[0]
+	if strings.TrimSpace(input) != "" {
+		if skipped, err = resolveMember(input, remaining); err != nil {
+			return MemberResult{}, err
+		}
+	}
+
+	remaining = append(removeName(remaining, skipped), skipped)
+	if err := saveRemaining(remaining, p.stateFile); err != nil {
+		return MemberResult{}, err
+	}
+	if err := recordHistory(p.historyFile, skipped, ActionSkip); err != nil {
+		return MemberResult{}, err
+	}
+
+	p.emit(Event{Type: EventSkip, Name: skipped, Action: ActionSkip, Remaining: remaining})
+	return MemberResult{Name: skipped, Action: ActionSkip, Remaining: remaining}, nil
+}
+
+// MarkAbsent takes someone who is away today out of the current round
+func (p *Picker) MarkAbsent(input string) (MemberResult, error) {
+	remaining, _, err := p.CurrentRound()
+	if err != nil {
+		return MemberResult{}, err
+	}
+
+	absent, err := resolveMember(input, remaining)
+	if err != nil {
+		return MemberResult{}, err
+	}
+
+	remaining = removeName(remaining, absent)
+	if err := saveRemaining(remaining, p.stateFile); err != nil {
+		return MemberResult{}, err
+	}
+	if err := recordHistory(p.historyFile, absent, ActionAbsent); err != nil {
+		return MemberResult{}, err
+	}
+
+	if len(remaining) == 0 {
+		p.guests = nil
+	}
+
+	p.emit(Event{Type: EventAbsent, Name: absent, Action: ActionAbsent, Remaining: remaining})
+	if len(remaining) == 0 {
+		p.emit(Event{Type: EventRoundComplete})
+	}
+	return MemberResult{Name: absent, Action: ActionAbsent, Remaining: remaining}, nil
+}
+
+// Undo reverts the last pick, skip or absence: the member is put back at the
+// front of the round and the entry is removed from the history
+func (p *Picker) Undo() (MemberResult, error) {
+	entry, err := removeLastHistoryEntry(p.historyFile)
+	if err != nil {
+		return MemberResult{}, err
+	}
+
+	remaining, err := loadRemaining(p.teamMembers, p.stateFile)
+	if err != nil {
+		return MemberResult{}, err
+	}
+	remaining = append([]string{entry.Name}, removeName(remaining, entry.Name)...)
+	if err := saveRemaining(remaining, p.stateFile); err != nil {
+		return MemberResult{}, err
+	}
+	p.trackGuests(remaining)
+
+	p.emit(Event{Type: EventUndo, Name: entry.Name, Action: entry.Action, Remaining: remaining})
+	return MemberResult{Name: entry.Name, Action: entry.Action, Remaining: remaining}, nil
+}
+
+// Reset starts over: everyone gets a turn again in a new shuffled round
+func (p *Picker) Reset() error {
+	p.guests = nil
+	// Remove state file to reset
+	if err := os.Remove(p.stateFile); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	p.emit(Event{Type: EventReset, Remaining: slices.Clone(p.teamMembers)})
+	return nil
+}
+
+// Status returns the current round, which is empty once everyone has had a
+// turn
+func (p *Picker) Status() (Status, error) {
+	var remaining []string
+	var err error
+	if _, statErr := os.Stat(p.stateFile); statErr == nil {
+		remaining, err = loadRemaining(p.teamMembers, p.stateFile)
+		p.trackGuests(remaining)
+	} else {
+		remaining, _, err = p.CurrentRound()
+	}
+	if err != nil {
+		return Status{}, err
+	}
+	if remaining == nil {
+		remaining = []string{}
+	}
+	return Status{
+		TeamMembers: len(p.teamMembers),
+		Remaining:   remaining,
+		Guests:      p.Guests(),
+	}, nil
+}
+
+// History returns past picks, skips and absences, oldest first
+func (p *Picker) History() ([]HistoryEntry, error) {
+	return loadHistory(p.historyFile)
+}
@@ -0,0 +1,262 @@
+package picker
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// Picker backed by state and history files in a temporary directory
+func newTestPicker(t *testing.T, teamMembers ...string) *Picker {
+	t.Helper()
+	dir := t.TempDir()
+	return New(teamMembers, filepath.Join(dir, "state.txt"), filepath.Join(dir, "history.txt"))
+}
+
+// Start the round with the given remaining members
+func setRound(t *testing.T, p *Picker, names ...string) {
+	t.Helper()
+	if err := saveRemaining(names, p.stateFile); err != nil {
+		t.Fatalf("Failed to save the round: %v", err)
+	}
+}
+
+func remainingOf(t *testing.T, p *Picker) []string {
+	t.Helper()
+	status, err := p.Status()
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	return status.Remaining
+}
+
+func TestCurrentRound_StartsAndSavesNewRound(t *testing.T) {
+	p := newTestPicker(t, "Alice", "Bob", "Charlie")
+
+	remaining, newRound, err := p.CurrentRound()
+	if err != nil || newRound || len(remaining) != 3 {
+		t.Fatalf("Expected the first round to start with everyone, got %v (newRound=%v, err=%v)", remaining, newRound, err)
+	}
+
+	// The shuffled order is saved, so the next call sees the same round
+	again, newRound, _ := p.CurrentRound()
+	if newRound || !slices.Equal(again, remaining) {
+		t.Errorf("Expected the same round %v, got %v (newRound=%v)", remaining, again, newRound)
+	}
+
+	// Once everyone has had a turn, a new round is started without the guests
+	setRound(t, p)
+	p.guests = []string{"Carol"}
+	if remaining := remainingOf(t, p); len(remaining) != 0 {
+		t.Errorf("Expected status to show an empty round, got %v", remaining)
+	}
+	remaining, newRound, _ = p.CurrentRound()
+	if !newRound || len(remaining) != 3 {
+		t.Errorf("Expected a new round with everyone, got %v (newRound=%v)", remaining, newRound)
+	}
+	if len(p.Guests()) != 0 {
+		t.Errorf("Expected guests to be cleared for the new round, got %v", p.Guests())
+	}
+}
+
+func TestRemaining_DoesNotStartRound(t *testing.T) {
+	p := newTestPicker(t, "Alice", "Bob")
+
+	remaining, err := p.Remaining()
+	if err != nil || len(remaining) != 0 {
+		t.Errorf("Expected nobody before the first round, got %v (err=%v)", remaining, err)
+	}
+	if _, err := os.Stat(p.stateFile); !os.IsNotExist(err) {
+		t.Errorf("Expected no state file to be saved, got %v", err)
+	}
+
+	setRound(t, p, "Bob", "Carol")
+	remaining, err = p.Remaining()
+	if err != nil || !slices.Equal(remaining, []string{"Bob", "Carol"}) {
+		t.Errorf("Expected the saved round, got %v (err=%v)", remaining, err)
+	}
+	if !p.IsGuest("Carol") {
+		t.Error("Expected Carol to be known as a guest")
+	}
+}
+
+func TestPick_Manual(t *testing.T) {
+	p := newTestPicker(t, "Alice", "Bob", "Charlie")
+	setRound(t, p, "Alice", "Bob", "Charlie")
+
+	result, err := p.Pick("2")
+	if err != nil {
+		t.Fatalf("Pick failed: %v", err)
+	}
+	if result.Name != "Bob" || result.Action != ActionManualPick {
+		t.Errorf("Expected a manual pick of Bob, got %+v", result)
+	}
+	if remaining := remainingOf(t, p); strings.Join(remaining, ",") != "Alice,Charlie" {
+		t.Errorf("Expected remaining [Alice Charlie], got %v", remaining)
+	}
+
+	entries, err := p.History()
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name != "Bob" || entries[0].Action != ActionManualPick {
+		t.Errorf("Expected a manual history entry for Bob, got %+v", entries)
+	}
+}
+
+func TestPick_UnknownNameLeavesStateUntouched(t *testing.T) {
+	p := newTestPicker(t, "Alice", "Bob")
+	setRound(t, p, "Alice", "Bob")
+
+	if _, err := p.Pick("Zoe"); !errors.Is(err, ErrNoSuchMember) {
+		t.Errorf("Expected ErrNoSuchMember, got %v", err)
+	}
+	if remaining := remainingOf(t, p); len(remaining) != 2 {
+		t.Errorf("Expected state to be untouched, got %v", remaining)
+	}
+	if _, err := os.Stat(p.historyFile); !os.IsNotExist(err) {
+		t.Errorf("Expected no history to be recorded, got err=%v", err)
+	}
+}
+
+func TestEmptyTeam(t *testing.T) {
+	p := newTestPicker(t)
+
+	if _, err := p.Pick(""); !errors.Is(err, ErrNoMembers) {
+		t.Errorf("Pick: expected ErrNoMembers, got %v", err)
+	}
+	if _, err := p.Skip(""); !errors.Is(err, ErrNoMembers) {
+		t.Errorf("Skip: expected ErrNoMembers, got %v", err)
+	}
+	if _, err := p.MarkAbsent("Alice"); !errors.Is(err, ErrNoMembers) {
+		t.Errorf("MarkAbsent: expected ErrNoMembers, got %v", err)
+	}
+}
+
+func TestPick_EmitsRoundComplete(t *testing.T) {
+	p := newTestPicker(t, "Alice", "Bob")
+	setRound(t, p, "Bob")
+	var events []string
+	p.Subscribe(func(event Event) {
+		events = append(events, event.Type+":"+event.Name)
+	})
+
+	if _, err := p.Pick(""); err != nil {
+		t.Fatalf("Pick failed: %v", err)
+	}
+	if strings.Join(events, ",") != "pick:Bob,roundComplete:" {
+		t.Errorf("Expected a pick then the end of the round, got %v", events)
+	}
+}
+
+func TestUndo_LastPickOfRound(t *testing.T) {
+	p := newTestPicker(t, "Alice", "Bob")
+	setRound(t, p, "Bob")
+
+	if _, err := p.Pick(""); err != nil {
+		t.Fatalf("Pick failed: %v", err)
+	}
+	if remaining := remainingOf(t, p); len(remaining) != 0 {
+		t.Fatalf("Expected the round to be over, got %v", remaining)
+	}
+
+	result, err := p.Undo()
+	if err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if result.Name != "Bob" || !slices.Equal(remainingOf(t, p), []string{"Bob"}) {
+		t.Errorf("Expected Bob to be back in the round, got %+v", result)
+	}
+
+	if _, err := p.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("Expected ErrNothingToUndo once history is empty, got %v", err)
+	}
+}
+
+func TestAddGuest(t *testing.T) {
+	p := newTestPicker(t, "Alice", "Bob")
+	setRound(t, p, "Alice", "Bob")
+
+	if err := p.AddGuest("  Carol "); err != nil {
+		t.Fatalf("AddGuest failed: %v", err)
+	}
+	if remaining := remainingOf(t, p); len(remaining) != 3 || !slices.Contains(remaining, "Carol") {
+		t.Errorf("Expected Carol to join the round, got %v", remaining)
+	}
+	if !p.IsGuest("Carol") {
+		t.Errorf("Expected Carol to be tracked as a guest, got %v", p.Guests())
+	}
+
+	tests := []struct {
+		name     string
+		expected error
+	}{
+		{name: "", expected: ErrNoName},
+		{name: "alice", expected: ErrGuestIsMember},
+		{name: "carol", expected: ErrAlreadyInRound},
+	}
+	for _, tt := range tests {
+		if err := p.AddGuest(tt.name); !errors.Is(err, tt.expected) {
+			t.Errorf("AddGuest(%q): expected %v, got %v", tt.name, tt.expected, err)
+		}
+	}
+	if len(p.Guests()) != 1 {
+		t.Errorf("Expected a single guest, got %v", p.Guests())
+	}
+}
+
+func TestUndo_GuestPickAfterRoundEnded(t *testing.T) {
+	p := newTestPicker(t, "Alice")
+	setRound(t, p, "Alice")
+	if err := p.AddGuest("Carol"); err != nil {
+		t.Fatalf("AddGuest failed: %v", err)
+	}
+	setRound(t, p, "Alice", "Carol")
+	for range 2 {
+		if _, err := p.Pick(""); err != nil {
+			t.Fatalf("Pick failed: %v", err)
+		}
+	}
+	if len(p.Guests()) != 0 {
+		t.Fatalf("Expected guests to be dropped with the round, got %v", p.Guests())
+	}
+
+	result, err := p.Undo()
+	if err != nil || result.Name != "Carol" {
+		t.Fatalf("Expected Carol's pick to be undone, got %+v (err=%v)", result, err)
+	}
+	if !p.IsGuest("Carol") {
+		t.Errorf("Expected Carol to still be a guest, got %v", p.Guests())
+	}
+	// Also for another run sharing the files
+	other := New(p.TeamMembers(), p.StateFile(), p.HistoryFile())
+	if err := other.EndMeeting(); err != nil {
+		t.Fatalf("EndMeeting failed: %v", err)
+	}
+	if remaining := remainingOf(t, other); slices.Contains(remaining, "Carol") {
+		t.Errorf("Expected Carol to be dropped at the end of the meeting, got %v", remaining)
+	}
+}
+
+func TestDropGuests(t *testing.T) {
+	p := newTestPicker(t, "Alice", "Bob")
+	setRound(t, p, "Alice", "Bob")
+	for _, name := range []string{"Carol", "Dave"} {
+		if err := p.AddGuest(name); err != nil {
+			t.Fatalf("AddGuest failed: %v", err)
+		}
+	}
+
+	if err := p.DropGuests(); err != nil {
+		t.Fatalf("DropGuests failed: %v", err)
+	}
+	if remaining := remainingOf(t, p); len(remaining) != 2 || slices.Contains(remaining, "Carol") || slices.Contains(remaining, "Dave") {
+		t.Errorf("Expected guests to be dropped from the round, got %v", remaining)
+	}
+	if len(p.Guests()) != 0 {
+		t.Errorf("Expected no guests left, got %v", p.Guests())
+	}
+}
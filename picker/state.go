@@ -0,0 +1,66 @@
+package picker
+
+import (
+	"bufio"
+	"errors"
+	"math/rand"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Load remaining names from file; if not exists, return full list shuffled
+func loadRemaining(teamMembers []string, stateFile string) (names []string, err error) {
+	file, err := os.Open(stateFile)
+	if os.IsNotExist(err) {
+		// File not found → start fresh
+		return shuffle(slices.Clone(teamMembers)), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		name := strings.TrimSpace(scanner.Text())
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, scanner.Err()
+}
+
+// Save remaining names to file. An empty file means everyone has had a turn
+// this round, while a missing file means no round has been started yet.
+func saveRemaining(names []string, stateFile string) error {
+	var content strings.Builder
+	for _, name := range names {
+		content.WriteString(name + "\n")
+	}
+	return os.WriteFile(stateFile, []byte(content.String()), 0o644)
+}
+
+// Shuffle a slice
+func shuffle(slice []string) []string {
+	rand.Shuffle(len(slice), func(i, j int) {
+		slice[i], slice[j] = slice[j], slice[i]
+	})
+	return slice
+}
+
+// Helper to remove the first occurrence of a name, preserving order
+func removeName(names []string, name string) []string {
+	result := make([]string, 0, len(names))
+	removed := false
+	for _, n := range names {
+		if !removed && n == name {
+			removed = true
+			continue
+		}
+		result = append(result, n)
+	}
+	return result
+}
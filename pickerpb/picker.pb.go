@@ -0,0 +1,569 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: picker.proto
+
+// gRPC API of the daily scrum picker, served by `daily-scrum-picker serve
+// --grpc-addr`. It acts on the same rounds as the CLI and the HTTP API.
+
+package pickerpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Request about a room only. Without a room, the default one is used
+// (i.e. the team file when the server has no teams directory).
+type RoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Room          string                 `protobuf:"bytes,1,opt,name=room,proto3" json:"room,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomRequest) Reset() {
+	*x = RoomRequest{}
+	mi := &file_picker_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomRequest) ProtoMessage() {}
+
+func (x *RoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_picker_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomRequest.ProtoReflect.Descriptor instead.
+func (*RoomRequest) Descriptor() ([]byte, []int) {
+	return file_picker_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RoomRequest) GetRoom() string {
+	if x != nil {
+		return x.Room
+	}
+	return ""
+}
+
+type PickRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Room  string                 `protobuf:"bytes,1,opt,name=room,proto3" json:"room,omitempty"`
+	// Name, prefix or number of the member to pick; the next in line if empty
+	Name          string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PickRequest) Reset() {
+	*x = PickRequest{}
+	mi := &file_picker_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PickRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PickRequest) ProtoMessage() {}
+
+func (x *PickRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_picker_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PickRequest.ProtoReflect.Descriptor instead.
+func (*PickRequest) Descriptor() ([]byte, []int) {
+	return file_picker_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PickRequest) GetRoom() string {
+	if x != nil {
+		return x.Room
+	}
+	return ""
+}
+
+func (x *PickRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type PickResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// "random" or "manual"
+	Action    string   `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Remaining []string `protobuf:"bytes,3,rep,name=remaining,proto3" json:"remaining,omitempty"`
+	// A new round was started for this pick
+	NewRound      bool `protobuf:"varint,4,opt,name=new_round,json=newRound,proto3" json:"new_round,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PickResponse) Reset() {
+	*x = PickResponse{}
+	mi := &file_picker_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PickResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PickResponse) ProtoMessage() {}
+
+func (x *PickResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_picker_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PickResponse.ProtoReflect.Descriptor instead.
+func (*PickResponse) Descriptor() ([]byte, []int) {
+	return file_picker_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PickResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PickResponse) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *PickResponse) GetRemaining() []string {
+	if x != nil {
+		return x.Remaining
+	}
+	return nil
+}
+
+func (x *PickResponse) GetNewRound() bool {
+	if x != nil {
+		return x.NewRound
+	}
+	return false
+}
+
+type MemberRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Room  string                 `protobuf:"bytes,1,opt,name=room,proto3" json:"room,omitempty"`
+	// Name, prefix or number of the member; required to mark someone absent
+	Name          string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemberRequest) Reset() {
+	*x = MemberRequest{}
+	mi := &file_picker_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemberRequest) ProtoMessage() {}
+
+func (x *MemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_picker_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemberRequest.ProtoReflect.Descriptor instead.
+func (*MemberRequest) Descriptor() ([]byte, []int) {
+	return file_picker_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MemberRequest) GetRoom() string {
+	if x != nil {
+		return x.Room
+	}
+	return ""
+}
+
+func (x *MemberRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type MemberResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// History action, e.g. "skip" or "absent", or the one undone
+	Action        string   `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Remaining     []string `protobuf:"bytes,3,rep,name=remaining,proto3" json:"remaining,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemberResponse) Reset() {
+	*x = MemberResponse{}
+	mi := &file_picker_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemberResponse) ProtoMessage() {}
+
+func (x *MemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_picker_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemberResponse.ProtoReflect.Descriptor instead.
+func (*MemberResponse) Descriptor() ([]byte, []int) {
+	return file_picker_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MemberResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MemberResponse) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *MemberResponse) GetRemaining() []string {
+	if x != nil {
+		return x.Remaining
+	}
+	return nil
+}
+
+type StatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamMembers   int32                  `protobuf:"varint,1,opt,name=team_members,json=teamMembers,proto3" json:"team_members,omitempty"`
+	Remaining     []string               `protobuf:"bytes,2,rep,name=remaining,proto3" json:"remaining,omitempty"`
+	Guests        []string               `protobuf:"bytes,3,rep,name=guests,proto3" json:"guests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusResponse) Reset() {
+	*x = StatusResponse{}
+	mi := &file_picker_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse) ProtoMessage() {}
+
+func (x *StatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_picker_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
+func (*StatusResponse) Descriptor() ([]byte, []int) {
+	return file_picker_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StatusResponse) GetTeamMembers() int32 {
+	if x != nil {
+		return x.TeamMembers
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetRemaining() []string {
+	if x != nil {
+		return x.Remaining
+	}
+	return nil
+}
+
+func (x *StatusResponse) GetGuests() []string {
+	if x != nil {
+		return x.Guests
+	}
+	return nil
+}
+
+// Change to the round, as sent to live clients of the HTTP API
+type Event struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "pick", "skip", "absent", "undo", "reset" or "roundComplete"
+	Type string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Time *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	// Member the event is about, if any
+	Name          string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Action        string   `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	Remaining     []string `protobuf:"bytes,5,rep,name=remaining,proto3" json:"remaining,omitempty"`
+	NewRound      bool     `protobuf:"varint,6,opt,name=new_round,json=newRound,proto3" json:"new_round,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_picker_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_picker_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_picker_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Event) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Event) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *Event) GetRemaining() []string {
+	if x != nil {
+		return x.Remaining
+	}
+	return nil
+}
+
+func (x *Event) GetNewRound() bool {
+	if x != nil {
+		return x.NewRound
+	}
+	return false
+}
+
+var File_picker_proto protoreflect.FileDescriptor
+
+const file_picker_proto_rawDesc = "" +
+	"\n" +
+	"\fpicker.proto\x12\rdailyscrum.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"!\n" +
+	"\vRoomRequest\x12\x12\n" +
+	"\x04room\x18\x01 \x01(\tR\x04room\"5\n" +
+	"\vPickRequest\x12\x12\n" +
+	"\x04room\x18\x01 \x01(\tR\x04room\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"u\n" +
+	"\fPickResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x1c\n" +
+	"\tremaining\x18\x03 \x03(\tR\tremaining\x12\x1b\n" +
+	"\tnew_round\x18\x04 \x01(\bR\bnewRound\"7\n" +
+	"\rMemberRequest\x12\x12\n" +
+	"\x04room\x18\x01 \x01(\tR\x04room\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"Z\n" +
+	"\x0eMemberResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x1c\n" +
+	"\tremaining\x18\x03 \x03(\tR\tremaining\"i\n" +
+	"\x0eStatusResponse\x12!\n" +
+	"\fteam_members\x18\x01 \x01(\x05R\vteamMembers\x12\x1c\n" +
+	"\tremaining\x18\x02 \x03(\tR\tremaining\x12\x16\n" +
+	"\x06guests\x18\x03 \x03(\tR\x06guests\"\xb2\x01\n" +
+	"\x05Event\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12\x1c\n" +
+	"\tremaining\x18\x05 \x03(\tR\tremaining\x12\x1b\n" +
+	"\tnew_round\x18\x06 \x01(\bR\bnewRound2\xeb\x03\n" +
+	"\x06Picker\x12?\n" +
+	"\x04Pick\x12\x1a.dailyscrum.v1.PickRequest\x1a\x1b.dailyscrum.v1.PickResponse\x12C\n" +
+	"\x04Skip\x12\x1c.dailyscrum.v1.MemberRequest\x1a\x1d.dailyscrum.v1.MemberResponse\x12I\n" +
+	"\n" +
+	"MarkAbsent\x12\x1c.dailyscrum.v1.MemberRequest\x1a\x1d.dailyscrum.v1.MemberResponse\x12A\n" +
+	"\x04Undo\x12\x1a.dailyscrum.v1.RoomRequest\x1a\x1d.dailyscrum.v1.MemberResponse\x12B\n" +
+	"\x05Reset\x12\x1a.dailyscrum.v1.RoomRequest\x1a\x1d.dailyscrum.v1.StatusResponse\x12F\n" +
+	"\tGetStatus\x12\x1a.dailyscrum.v1.RoomRequest\x1a\x1d.dailyscrum.v1.StatusResponse\x12A\n" +
+	"\vWatchEvents\x12\x1a.dailyscrum.v1.RoomRequest\x1a\x14.dailyscrum.v1.Event0\x01B-Z+github.com/rm3l/daily-scrum-picker/pickerpbb\x06proto3"
+
+var (
+	file_picker_proto_rawDescOnce sync.Once
+	file_picker_proto_rawDescData []byte
+)
+
+func file_picker_proto_rawDescGZIP() []byte {
+	file_picker_proto_rawDescOnce.Do(func() {
+		file_picker_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_picker_proto_rawDesc), len(file_picker_proto_rawDesc)))
+	})
+	return file_picker_proto_rawDescData
+}
+
+var file_picker_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_picker_proto_goTypes = []any{
+	(*RoomRequest)(nil),           // 0: dailyscrum.v1.RoomRequest
+	(*PickRequest)(nil),           // 1: dailyscrum.v1.PickRequest
+	(*PickResponse)(nil),          // 2: dailyscrum.v1.PickResponse
+	(*MemberRequest)(nil),         // 3: dailyscrum.v1.MemberRequest
+	(*MemberResponse)(nil),        // 4: dailyscrum.v1.MemberResponse
+	(*StatusResponse)(nil),        // 5: dailyscrum.v1.StatusResponse
+	(*Event)(nil),                 // 6: dailyscrum.v1.Event
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+}
+var file_picker_proto_depIdxs = []int32{
+	7, // 0: dailyscrum.v1.Event.time:type_name -> google.protobuf.Timestamp
+	1, // 1: dailyscrum.v1.Picker.Pick:input_type -> dailyscrum.v1.PickRequest
+	3, // 2: dailyscrum.v1.Picker.Skip:input_type -> dailyscrum.v1.MemberRequest
+	3, // 3: dailyscrum.v1.Picker.MarkAbsent:input_type -> dailyscrum.v1.MemberRequest
+	0, // 4: dailyscrum.v1.Picker.Undo:input_type -> dailyscrum.v1.RoomRequest
+	0, // 5: dailyscrum.v1.Picker.Reset:input_type -> dailyscrum.v1.RoomRequest
+	0, // 6: dailyscrum.v1.Picker.GetStatus:input_type -> dailyscrum.v1.RoomRequest
+	0, // 7: dailyscrum.v1.Picker.WatchEvents:input_type -> dailyscrum.v1.RoomRequest
+	2, // 8: dailyscrum.v1.Picker.Pick:output_type -> dailyscrum.v1.PickResponse
+	4, // 9: dailyscrum.v1.Picker.Skip:output_type -> dailyscrum.v1.MemberResponse
+	4, // 10: dailyscrum.v1.Picker.MarkAbsent:output_type -> dailyscrum.v1.MemberResponse
+	4, // 11: dailyscrum.v1.Picker.Undo:output_type -> dailyscrum.v1.MemberResponse
+	5, // 12: dailyscrum.v1.Picker.Reset:output_type -> dailyscrum.v1.StatusResponse
+	5, // 13: dailyscrum.v1.Picker.GetStatus:output_type -> dailyscrum.v1.StatusResponse
+	6, // 14: dailyscrum.v1.Picker.WatchEvents:output_type -> dailyscrum.v1.Event
+	8, // [8:15] is the sub-list for method output_type
+	1, // [1:8] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_picker_proto_init() }
+func file_picker_proto_init() {
+	if File_picker_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_picker_proto_rawDesc), len(file_picker_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_picker_proto_goTypes,
+		DependencyIndexes: file_picker_proto_depIdxs,
+		MessageInfos:      file_picker_proto_msgTypes,
+	}.Build()
+	File_picker_proto = out.File
+	file_picker_proto_goTypes = nil
+	file_picker_proto_depIdxs = nil
+}
@@ -0,0 +1,378 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: picker.proto
+
+// gRPC API of the daily scrum picker, served by `daily-scrum-picker serve
+// --grpc-addr`. It acts on the same rounds as the CLI and the HTTP API.
+
+package pickerpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Picker_Pick_FullMethodName        = "/dailyscrum.v1.Picker/Pick"
+	Picker_Skip_FullMethodName        = "/dailyscrum.v1.Picker/Skip"
+	Picker_MarkAbsent_FullMethodName  = "/dailyscrum.v1.Picker/MarkAbsent"
+	Picker_Undo_FullMethodName        = "/dailyscrum.v1.Picker/Undo"
+	Picker_Reset_FullMethodName       = "/dailyscrum.v1.Picker/Reset"
+	Picker_GetStatus_FullMethodName   = "/dailyscrum.v1.Picker/GetStatus"
+	Picker_WatchEvents_FullMethodName = "/dailyscrum.v1.Picker/WatchEvents"
+)
+
+// PickerClient is the client API for Picker service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Runs the stand-up of a room. Requests are authenticated like the HTTP API,
+// with an "authorization: Bearer <token>" metadata entry when the room has
+// access tokens.
+type PickerClient interface {
+	// Pick the next person, or a specific remaining member
+	Pick(ctx context.Context, in *PickRequest, opts ...grpc.CallOption) (*PickResponse, error)
+	// Move someone (the next in line by default) to the end of the round
+	Skip(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*MemberResponse, error)
+	// Take someone who is away today out of the round
+	MarkAbsent(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*MemberResponse, error)
+	// Undo the last pick, skip or absence
+	Undo(ctx context.Context, in *RoomRequest, opts ...grpc.CallOption) (*MemberResponse, error)
+	// Reset and start over
+	Reset(ctx context.Context, in *RoomRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	// Team size and remaining members of the current round
+	GetStatus(ctx context.Context, in *RoomRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	// Stream every change to the round, until the client cancels
+	WatchEvents(ctx context.Context, in *RoomRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+}
+
+type pickerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPickerClient(cc grpc.ClientConnInterface) PickerClient {
+	return &pickerClient{cc}
+}
+
+func (c *pickerClient) Pick(ctx context.Context, in *PickRequest, opts ...grpc.CallOption) (*PickResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PickResponse)
+	err := c.cc.Invoke(ctx, Picker_Pick_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pickerClient) Skip(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*MemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MemberResponse)
+	err := c.cc.Invoke(ctx, Picker_Skip_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pickerClient) MarkAbsent(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*MemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MemberResponse)
+	err := c.cc.Invoke(ctx, Picker_MarkAbsent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pickerClient) Undo(ctx context.Context, in *RoomRequest, opts ...grpc.CallOption) (*MemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MemberResponse)
+	err := c.cc.Invoke(ctx, Picker_Undo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pickerClient) Reset(ctx context.Context, in *RoomRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, Picker_Reset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pickerClient) GetStatus(ctx context.Context, in *RoomRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, Picker_GetStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pickerClient) WatchEvents(ctx context.Context, in *RoomRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Picker_ServiceDesc.Streams[0], Picker_WatchEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RoomRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Picker_WatchEventsClient = grpc.ServerStreamingClient[Event]
+
+// PickerServer is the server API for Picker service.
+// All implementations must embed UnimplementedPickerServer
+// for forward compatibility.
+//
+// Runs the stand-up of a room. Requests are authenticated like the HTTP API,
+// with an "authorization: Bearer <token>" metadata entry when the room has
+// access tokens.
+type PickerServer interface {
+	// Pick the next person, or a specific remaining member
+	Pick(context.Context, *PickRequest) (*PickResponse, error)
+	// Move someone (the next in line by default) to the end of the round
+	Skip(context.Context, *MemberRequest) (*MemberResponse, error)
+	// Take someone who is away today out of the round
+	MarkAbsent(context.Context, *MemberRequest) (*MemberResponse, error)
+	// Undo the last pick, skip or absence
+	Undo(context.Context, *RoomRequest) (*MemberResponse, error)
+	// Reset and start over
+	Reset(context.Context, *RoomRequest) (*StatusResponse, error)
+	// Team size and remaining members of the current round
+	GetStatus(context.Context, *RoomRequest) (*StatusResponse, error)
+	// Stream every change to the round, until the client cancels
+	WatchEvents(*RoomRequest, grpc.ServerStreamingServer[Event]) error
+	mustEmbedUnimplementedPickerServer()
+}
+
+// UnimplementedPickerServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPickerServer struct{}
+
+func (UnimplementedPickerServer) Pick(context.Context, *PickRequest) (*PickResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pick not implemented")
+}
+func (UnimplementedPickerServer) Skip(context.Context, *MemberRequest) (*MemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Skip not implemented")
+}
+func (UnimplementedPickerServer) MarkAbsent(context.Context, *MemberRequest) (*MemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkAbsent not implemented")
+}
+func (UnimplementedPickerServer) Undo(context.Context, *RoomRequest) (*MemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Undo not implemented")
+}
+func (UnimplementedPickerServer) Reset(context.Context, *RoomRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reset not implemented")
+}
+func (UnimplementedPickerServer) GetStatus(context.Context, *RoomRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedPickerServer) WatchEvents(*RoomRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
+func (UnimplementedPickerServer) mustEmbedUnimplementedPickerServer() {}
+func (UnimplementedPickerServer) testEmbeddedByValue()                {}
+
+// UnsafePickerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PickerServer will
+// result in compilation errors.
+type UnsafePickerServer interface {
+	mustEmbedUnimplementedPickerServer()
+}
+
+func RegisterPickerServer(s grpc.ServiceRegistrar, srv PickerServer) {
+	// If the following call pancis, it indicates UnimplementedPickerServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Picker_ServiceDesc, srv)
+}
+
+func _Picker_Pick_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PickRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PickerServer).Pick(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Picker_Pick_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PickerServer).Pick(ctx, req.(*PickRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Picker_Skip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PickerServer).Skip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Picker_Skip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PickerServer).Skip(ctx, req.(*MemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Picker_MarkAbsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PickerServer).MarkAbsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Picker_MarkAbsent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PickerServer).MarkAbsent(ctx, req.(*MemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Picker_Undo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PickerServer).Undo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Picker_Undo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PickerServer).Undo(ctx, req.(*RoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Picker_Reset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PickerServer).Reset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Picker_Reset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PickerServer).Reset(ctx, req.(*RoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Picker_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PickerServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Picker_GetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PickerServer).GetStatus(ctx, req.(*RoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Picker_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RoomRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PickerServer).WatchEvents(m, &grpc.GenericServerStream[RoomRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Picker_WatchEventsServer = grpc.ServerStreamingServer[Event]
+
+// Picker_ServiceDesc is the grpc.ServiceDesc for Picker service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Picker_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dailyscrum.v1.Picker",
+	HandlerType: (*PickerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Pick",
+			Handler:    _Picker_Pick_Handler,
+		},
+		{
+			MethodName: "Skip",
+			Handler:    _Picker_Skip_Handler,
+		},
+		{
+			MethodName: "MarkAbsent",
+			Handler:    _Picker_MarkAbsent_Handler,
+		},
+		{
+			MethodName: "Undo",
+			Handler:    _Picker_Undo_Handler,
+		},
+		{
+			MethodName: "Reset",
+			Handler:    _Picker_Reset_Handler,
+		},
+		{
+			MethodName: "GetStatus",
+			Handler:    _Picker_GetStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _Picker_WatchEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "picker.proto",
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+var errPromptCancelled = errors.New("cancelled")
+
+// Return the candidates starting with prefix (case-insensitive)
+func completeName(prefix string, candidates []string) []string {
+	var matches []string
+	lowerPrefix := strings.ToLower(prefix)
+	for _, candidate := range candidates {
+		if strings.HasPrefix(strings.ToLower(candidate), lowerPrefix) {
+			matches = append(matches, candidate)
+		}
+	}
+	return matches
+}
+
+// Longest prefix shared by all names, compared case-insensitively but
+// returned with the casing of the first name
+func commonPrefix(names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	prefix := []rune(names[0])
+	for _, name := range names[1:] {
+		runes := []rune(name)
+		i := 0
+		for i < len(prefix) && i < len(runes) &&
+			strings.EqualFold(string(prefix[i]), string(runes[i])) {
+			i++
+		}
+		prefix = prefix[:i]
+	}
+	return string(prefix)
+}
+
+// Read a line from a terminal in raw mode, handling echo, backspace and
+// Tab completion against the candidates. Ctrl+C and Esc cancel the prompt.
+func readLineRaw(r io.Reader, w io.Writer, prompt string, candidates []string) (string, error) {
+	editor := &lineEditor{
+		in:            r,
+		out:           w,
+		echo:          true,
+		escapeCancels: true,
+		complete: func(line string) []string {
+			return completeName(line, candidates)
+		},
+	}
+	return editor.readLine(prompt)
+}
+
+// Minimal readline-style line editor with backspace, Ctrl+U, Up/Down history
+// and Tab completion. In raw mode it echoes input itself. Otherwise there is
+// no terminal to take keys from (e.g. docker run -i): the line only arrives
+// on Enter, edited by the user's own terminal, so keys such as Tab are
+// applied to the whole line at once, and the outcome is shown when it
+// differs from what was typed.
+type lineEditor struct {
+	in  io.Reader
+	out io.Writer
+
+	// Echo typed characters (raw mode)
+	echo bool
+	// Treat Esc as cancel rather than the start of an arrow key sequence
+	escapeCancels bool
+	// Return the full-line candidates for the current line
+	complete func(line string) []string
+
+	history   []string
+	lastWasCR bool
+	// Bytes read but not handled yet. The escape sequence of a special key
+	// arrives in a single read, unlike a lone Esc followed by another key.
+	pending []byte
+}
+
+// Remember a line for Up/Down navigation, ignoring blanks and repeats
+func (e *lineEditor) addHistory(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" || (len(e.history) > 0 && e.history[len(e.history)-1] == line) {
+		return
+	}
+	e.history = append(e.history, line)
+}
+
+// Read the answer to a prompt, completing against the given names
+func (e *lineEditor) ask(prompt string, names []string) (string, error) {
+	complete := e.complete
+	defer func() { e.complete = complete }()
+	e.complete = func(line string) []string {
+		return completeName(line, names)
+	}
+	return e.readLine(prompt)
+}
+
+func (e *lineEditor) readByte() (byte, error) {
+	for len(e.pending) == 0 {
+		buf := make([]byte, 64)
+		n, err := e.in.Read(buf)
+		e.pending = buf[:n]
+		if n == 0 && err != nil {
+			return 0, err
+		}
+	}
+	char := e.pending[0]
+	e.pending = e.pending[1:]
+	return char, nil
+}
+
+// Whether the Esc just read starts the escape sequence of a special key, e.g.
+// ESC [ A for Up, or ESC O A in application mode
+func (e *lineEditor) escapeSequenceFollows() bool {
+	return len(e.pending) > 0 && (e.pending[0] == '[' || e.pending[0] == 'O')
+}
+
+// Consume the rest of an escape sequence (e.g. "[A" for Up or "[3~" for
+// Delete) and return its final byte
+func (e *lineEditor) escapeSequence() byte {
+	e.pending = e.pending[1:]
+	for len(e.pending) > 0 {
+		b := e.pending[0]
+		e.pending = e.pending[1:]
+		if b >= 0x40 && b <= 0x7e {
+			return b
+		}
+	}
+	return 0
+}
+
+func (e *lineEditor) readLine(prompt string) (string, error) {
+	line := ""
+	edited := false
+	historyPos := len(e.history)
+	draft := ""
+
+	redraw := func() {
+		if e.echo {
+			_, _ = fmt.Fprintf(e.out, "\r\033[K%s%s", prompt, line)
+		}
+	}
+	finish := func() (string, error) {
+		if e.echo {
+			_, _ = fmt.Fprint(e.out, "\r\n")
+		} else if edited {
+			// Show what history or completion turned the typed line into,
+			// after the prompt that is already there
+			_, _ = fmt.Fprintln(e.out, line)
+		}
+		return line, nil
+	}
+
+	_, _ = fmt.Fprint(e.out, prompt)
+	for {
+		char, err := e.readByte()
+		if err != nil {
+			if errors.Is(err, io.EOF) && line != "" {
+				return finish()
+			}
+			return "", err
+		}
+
+		// Swallow the '\n' of a "\r\n" line ending
+		if e.lastWasCR && char == '\n' {
+			e.lastWasCR = false
+			continue
+		}
+		e.lastWasCR = char == '\r'
+
+		switch {
+		case char == 3: // Ctrl+C
+			_, _ = fmt.Fprint(e.out, "\r\n")
+			return "", errPromptCancelled
+		case char == 4 && line == "": // Ctrl+D
+			return "", io.EOF
+		case char == 27 && !e.escapeSequenceFollows(): // Esc
+			if e.escapeCancels {
+				_, _ = fmt.Fprint(e.out, "\r\n")
+				return "", errPromptCancelled
+			}
+		case char == 27: // Special key, e.g. an arrow
+			switch key := e.escapeSequence(); {
+			case key == 'A' && historyPos > 0:
+				if historyPos == len(e.history) {
+					draft = line
+				}
+				historyPos--
+				line = e.history[historyPos]
+			case key == 'B' && historyPos < len(e.history):
+				historyPos++
+				if historyPos == len(e.history) {
+					line = draft
+				} else {
+					line = e.history[historyPos]
+				}
+			default:
+				continue
+			}
+			edited = true
+			redraw()
+		case char == '\r' || char == '\n':
+			return finish()
+		case char == 127 || char == 8: // Backspace
+			if line != "" {
+				_, size := utf8.DecodeLastRuneInString(line)
+				line = line[:len(line)-size]
+				edited = true
+				redraw()
+			}
+		case char == 21: // Ctrl+U
+			line = ""
+			edited = true
+			redraw()
+		case char == '\t':
+			if e.complete == nil {
+				continue
+			}
+			before := line
+			matches := e.complete(line)
+			switch {
+			case len(matches) == 1:
+				line = matches[0]
+			case len(matches) > 1:
+				if prefix := commonPrefix(matches); utf8.RuneCountInString(prefix) > utf8.RuneCountInString(line) {
+					line = prefix
+				} else {
+					listCompletions(e.out, line, matches, e.echo)
+				}
+			}
+			edited = edited || line != before
+			redraw()
+		case char >= 32:
+			// Printable ASCII or part of a multi-byte UTF-8 sequence
+			line += string([]byte{char})
+			if e.echo {
+				_, _ = e.out.Write([]byte{char})
+			}
+		}
+	}
+}
+
+// Print ambiguous completions, leaving out what was typed before the word
+// being completed
+func listCompletions(w io.Writer, line string, matches []string, raw bool) {
+	head := len(line[:strings.LastIndex(line, " ")+1])
+	words := make([]string, len(matches))
+	for i, match := range matches {
+		words[i] = match[head:]
+	}
+	newline := "\n"
+	if raw {
+		newline = "\r\n"
+	}
+	_, _ = fmt.Fprintf(w, "%s%s%s", newline, strings.Join(words, "  "), newline)
+}
+
+// Complete the command name, then a member name as its argument
+func completeCommandLine(line string, commands, names []string) []string {
+	if matches := completeName(line, commands); len(matches) > 0 {
+		return matches
+	}
+
+	// Find the (longest) command already typed in full
+	head := ""
+	for _, command := range commands {
+		if len(command) > len(head) && strings.HasPrefix(strings.ToLower(line), strings.ToLower(command)+" ") {
+			head = command + " "
+		}
+	}
+	if head == "" {
+		return nil
+	}
+
+	head = line[:len(head)]
+	var matches []string
+	for _, name := range completeName(line[len(head):], names) {
+		matches = append(matches, head+name)
+	}
+	return matches
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestReadLineRaw(t *testing.T) {
+	candidates := []string{"Alice", "Albert", "Bob"}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  error
+	}{
+		{name: "plain line", input: "Bob\r", expected: "Bob"},
+		{name: "tab completes unique prefix", input: "b\t\r", expected: "Bob"},
+		{name: "tab completes common prefix", input: "a\t\r", expected: "Al"},
+		{name: "tab then more typing", input: "a\tb\t\r", expected: "Albert"},
+		{name: "backspace", input: "Bobx\x7f\r", expected: "Bob"},
+		{name: "backspace on multi-byte rune", input: "Zoë\x7f\x7fe\r", expected: "Ze"},
+		{name: "escape cancels", input: "Bo\x1b", wantErr: errPromptCancelled},
+		{name: "special keys do not cancel", input: "B\x1b[Ao\x1b[3~\x1bOBb\r", expected: "Bob"},
+		{name: "ctrl+c cancels", input: "Bo\x03", wantErr: errPromptCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			result, err := readLineRaw(strings.NewReader(tt.input), &out, "> ", candidates)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readLineRaw failed: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("readLineRaw(%q) = %q; want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReadLineRaw_ListsAmbiguousMatches(t *testing.T) {
+	var out bytes.Buffer
+	if _, err := readLineRaw(strings.NewReader("Al\t\r"), &out, "> ", []string{"Alice", "Albert"}); err != nil {
+		t.Fatalf("readLineRaw failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Alice  Albert") {
+		t.Errorf("Expected ambiguous candidates to be listed, got %q", out.String())
+	}
+}
+
+func TestLineEditor_History(t *testing.T) {
+	editor := &lineEditor{in: strings.NewReader("\x1b[A\n\x1b[A\x1b[A\x1b[B\ndraft\x1b[A\x1b[B\n"), out: &bytes.Buffer{}}
+	editor.addHistory("pick")
+	editor.addHistory("status")
+	editor.addHistory("status")
+	editor.addHistory("  ")
+
+	expected := []string{"status", "status", "draft"}
+	for _, want := range expected {
+		line, err := editor.readLine("> ")
+		if err != nil {
+			t.Fatalf("readLine failed: %v", err)
+		}
+		if line != want {
+			t.Errorf("readLine() = %q; want %q", line, want)
+		}
+	}
+	if len(editor.history) != 2 {
+		t.Errorf("Expected blank and repeated lines to be left out of history, got %v", editor.history)
+	}
+}
+
+func TestLineEditor_LoneEscape(t *testing.T) {
+	editor := &lineEditor{in: strings.NewReader("\x1b\n\x1bpick\n"), out: &bytes.Buffer{}}
+
+	for _, want := range []string{"", "pick"} {
+		line, err := editor.readLine("> ")
+		if err != nil {
+			t.Fatalf("readLine failed: %v", err)
+		}
+		if line != want {
+			t.Errorf("readLine() = %q; want %q", line, want)
+		}
+	}
+}
+
+func TestLineEditor_BufferedModeEchoesOnlyEditedLines(t *testing.T) {
+	var out bytes.Buffer
+	editor := &lineEditor{
+		in:  strings.NewReader("status\r\npi\t\n"),
+		out: &out,
+		complete: func(line string) []string {
+			return completeCommandLine(line, bufferedCommands, nil)
+		},
+	}
+
+	for _, want := range []string{"status", "pick"} {
+		line, err := editor.readLine("> ")
+		if err != nil {
+			t.Fatalf("readLine failed: %v", err)
+		}
+		if line != want {
+			t.Errorf("readLine() = %q; want %q", line, want)
+		}
+	}
+	if _, err := editor.readLine("> "); !errors.Is(err, io.EOF) {
+		t.Errorf("Expected io.EOF at end of input, got %v", err)
+	}
+
+	if out.String() != "> > pick\n> " {
+		t.Errorf("Unexpected output %q", out.String())
+	}
+}
+
+func TestCompleteCommandLine(t *testing.T) {
+	names := []string{"Alice", "Albert", "Bob Smith"}
+
+	tests := []struct {
+		line     string
+		expected []string
+	}{
+		{line: "st", expected: []string{"status"}},
+		{line: "q", expected: []string{"quit"}},
+		{line: "manual al", expected: []string{"manual Alice", "manual Albert"}},
+		{line: "manual bob s", expected: []string{"manual Bob Smith"}},
+		{line: "manual z", expected: nil},
+	}
+
+	for _, tt := range tests {
+		result := completeCommandLine(tt.line, bufferedCommands, names)
+		if strings.Join(result, "|") != strings.Join(tt.expected, "|") {
+			t.Errorf("completeCommandLine(%q) = %v; want %v", tt.line, result, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,545 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+
+	"golang.org/x/term"
+
+	"github.com/rm3l/daily-scrum-picker/picker"
+)
+
+// Output formats of the CLI
+const (
+	OutputText     = "text"
+	OutputJSON     = "json"
+	OutputMarkdown = "markdown"
+)
+
+// When to color text output
+const (
+	// Colors when writing to a terminal, unless disabled by the environment
+	ColorModeAuto   = "auto"
+	ColorModeAlways = "always"
+	ColorModeNever  = "never"
+)
+
+// How results are shown, from the command-line flags
+type renderOptions struct {
+	output  string
+	color   string
+	noEmoji bool
+	// Colors of text output; the default theme if nil
+	theme theme
+	// Templates overriding messages of text output
+	messages map[string]string
+	// Language of text and Markdown output; English if nil
+	lang *language
+	// Screen-reader friendly text: no colors nor emojis, linear sentences and
+	// explicit changes of the round
+	accessible bool
+}
+
+// Renderer shows the outcome of session operations, so that the same results
+// can be printed in a terminal, logged or posted elsewhere. onDeck lists the
+// next speakers to give a heads-up to, if any.
+type Renderer interface {
+	// Banner of the interactive mode
+	Welcome(data welcomeData)
+	Pick(data pickData)
+	Skip(result picker.MemberResult, onDeck []string)
+	Absent(result picker.MemberResult)
+	Undo(result picker.MemberResult, onDeck []string)
+	GuestAdded(name string)
+	Reset(teamMembers int)
+	Status(status picker.Status)
+	// Remaining members to choose from for a manual pick
+	Candidates(remaining, guests []string)
+	Help()
+	// An operation failed, e.g. action "pick"
+	Error(action string, err error)
+}
+
+func newRenderer(w io.Writer, opts renderOptions) (Renderer, error) {
+	color, err := useColor(opts.color, w)
+	if err != nil {
+		return nil, err
+	}
+	emoji := !opts.noEmoji
+	messages := opts.messages
+	if opts.accessible {
+		color, emoji = false, false
+		// Overrides of the config file still apply
+		messages = maps.Clone(accessibleMessages)
+		maps.Copy(messages, opts.messages)
+	}
+	lang := opts.lang
+	if lang == nil {
+		lang = english
+	}
+
+	switch opts.output {
+	case OutputText, "":
+		t := opts.theme
+		if t == nil {
+			if t, err = loadTheme(defaultThemeName, ""); err != nil {
+				return nil, err
+			}
+		}
+		r := &textRenderer{w: w, color: color, theme: t, emoji: emoji, lang: lang, accessible: opts.accessible}
+		if r.messages, err = parseMessages(messages, messageFuncs(r)); err != nil {
+			return nil, err
+		}
+		return r, nil
+	case OutputJSON:
+		return &jsonRenderer{w: w}, nil
+	case OutputMarkdown:
+		return &markdownRenderer{w: w, emoji: emoji, lang: lang}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format '%s' (expected %s, %s or %s)",
+			opts.output, OutputText, OutputJSON, OutputMarkdown)
+	}
+}
+
+// Whether to color text written to w. In auto mode, NO_COLOR
+// (https://no-color.org) disables colors, CLICOLOR_FORCE forces them, and
+// otherwise only terminals other than dumb ones get colors.
+func useColor(mode string, w io.Writer) (bool, error) {
+	switch mode {
+	case ColorModeAlways:
+		return true, nil
+	case ColorModeNever:
+		return false, nil
+	case ColorModeAuto, "":
+	default:
+		return false, fmt.Errorf("unknown color mode '%s' (expected %s, %s or %s)",
+			mode, ColorModeAuto, ColorModeAlways, ColorModeNever)
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		return false, nil
+	}
+	if force := os.Getenv("CLICOLOR_FORCE"); force != "" && force != "0" {
+		return true, nil
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false, nil
+	}
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd())), nil
+}
+
+// Emoji prefix of a message, left out for terminals that do not render emojis
+func icon(enabled bool, emoji string) string {
+	if !enabled {
+		return ""
+	}
+	return emoji
+}
+
+// Keys of the interactive mode, shown by Help, with the id of their
+// description
+var helpKeys = []struct {
+	key, command, role, description string
+}{
+	{"p", "pick", roleSuccess, "help.pick"},
+	{"m", "manual", roleSuccess, "help.manual"},
+	{"g", "guest", roleSuccess, "help.guest"},
+	{"u", "undo", roleCommand, "help.undo"},
+	{"r", "reset", roleWarning, "help.reset"},
+	{"s", "status", roleHeading, "help.status"},
+	{"h", "help", roleCommand, "help.help"},
+	{"q", "quit", roleWarning, "help.quit"},
+}
+
+// Commands only available when typing them, shown by Help
+var helpCommands = []struct {
+	usage, description string
+}{
+	{"pick [name|number]", "help.pickName"},
+	{"skip [name|number]", "help.skipName"},
+	{"absent <name|number>", "help.absentName"},
+	{"add guest <name>", "help.addGuest"},
+	{"status --json", "help.statusJSON"},
+}
+
+// Id of the message of an undone action
+func undoMessage(action string) string {
+	switch action {
+	case picker.ActionSkip:
+		return "undid.skip"
+	case picker.ActionAbsent:
+		return "undid.absence"
+	default:
+		return "undid.pick"
+	}
+}
+
+// Human-readable text from the message templates, colored by the theme if
+// enabled
+type textRenderer struct {
+	w        io.Writer
+	color    bool
+	theme    theme
+	emoji    bool
+	lang     *language
+	messages map[string]*template.Template
+	// Tell how many remain after every change of the round
+	accessible bool
+}
+
+// Write the message, on its own line(s) unless it renders as nothing
+func (r *textRenderer) message(key string, data any) {
+	var b strings.Builder
+	if err := r.messages[key].Execute(&b, data); err != nil {
+		_, _ = fmt.Fprintf(r.w, "Warning: failed to render message '%s': %v\n", key, err)
+		return
+	}
+	if b.Len() > 0 {
+		_, _ = fmt.Fprintln(r.w, b.String())
+	}
+}
+
+// Text in the color of the role, if colors are enabled
+func (r *textRenderer) style(role, text string) string {
+	sequence := r.theme[role]
+	if !r.color || sequence == "" {
+		return text
+	}
+	return sequence + text + ansiReset
+}
+
+func (r *textRenderer) Welcome(data welcomeData) {
+	r.message("welcome", data)
+}
+
+func (r *textRenderer) Pick(data pickData) {
+	if data.NewRound {
+		r.message("newRound", data)
+	}
+	r.message("pick", data)
+	if len(data.Remaining) > 0 {
+		r.message("remaining", data)
+	} else {
+		r.message("lastPerson", data)
+	}
+	if len(data.OnDeck) > 0 {
+		r.message("onDeck", data)
+	}
+}
+
+func (r *textRenderer) Skip(result picker.MemberResult, onDeck []string) {
+	_, _ = fmt.Fprintln(r.w, icon(r.emoji, "⏭️  ")+r.lang.T("skipped", r.style(roleName, result.Name)))
+	if r.accessible {
+		r.remainingCount(result.Remaining)
+	}
+	r.onDeck(onDeck)
+}
+
+func (r *textRenderer) Absent(result picker.MemberResult) {
+	_, _ = fmt.Fprintln(r.w, r.lang.T("absent", r.style(roleName, result.Name)))
+	r.remainingCount(result.Remaining)
+}
+
+func (r *textRenderer) Undo(result picker.MemberResult, onDeck []string) {
+	_, _ = fmt.Fprintln(r.w, icon(r.emoji, "↩️  ")+r.lang.T(undoMessage(result.Action), r.style(roleName, result.Name)))
+	if r.accessible {
+		r.remainingCount(result.Remaining)
+	}
+	r.onDeck(onDeck)
+}
+
+func (r *textRenderer) GuestAdded(name string) {
+	_, _ = fmt.Fprintln(r.w, icon(r.emoji, "👋 ")+r.lang.T("guestAdded", r.style(roleName, name)))
+}
+
+func (r *textRenderer) Reset(teamMembers int) {
+	r.message("reset", resetData{TeamMembers: teamMembers})
+}
+
+func (r *textRenderer) Status(status picker.Status) {
+	r.message("status", statusData{status})
+}
+
+func (r *textRenderer) Candidates(remaining, guests []string) {
+	for i, name := range remaining {
+		_, _ = fmt.Fprintf(r.w, "  %s %s\n", r.style(roleAccent, fmt.Sprintf("%2d.", i+1)), guestLabel(r.lang, name, guests))
+	}
+}
+
+func (r *textRenderer) Help() {
+	_, _ = fmt.Fprintf(r.w, "\n%s\n", r.style(roleHeading, icon(r.emoji, "📋 ")+r.lang.T("help.heading")))
+	for _, k := range helpKeys {
+		_, _ = fmt.Fprintf(r.w, "  %s, %-6s - %s\n", r.style(k.role, k.key), k.command, r.lang.T(k.description))
+	}
+	_, _ = fmt.Fprintf(r.w, "\n%s\n", r.style(roleHeading, r.lang.T("help.typing")))
+	for _, c := range helpCommands {
+		_, _ = fmt.Fprintf(r.w, "  %-21s - %s\n", c.usage, r.lang.T(c.description))
+	}
+	_, _ = fmt.Fprintln(r.w)
+}
+
+func (r *textRenderer) Error(action string, err error) {
+	_, _ = fmt.Fprintln(r.w, r.style(roleWarning, r.lang.cannot(action, err)))
+}
+
+func (r *textRenderer) remainingCount(remaining []string) {
+	if len(remaining) > 0 {
+		r.message("remaining", pickData{Remaining: remaining})
+	} else {
+		r.message("lastPerson", pickData{})
+	}
+}
+
+// Give the next speakers a heads-up so they can prepare
+func (r *textRenderer) onDeck(onDeck []string) {
+	if len(onDeck) > 0 {
+		r.message("onDeck", pickData{OnDeck: onDeck})
+	}
+}
+
+func guestLabel(lang *language, name string, guests []string) string {
+	if slices.Contains(guests, name) {
+		return lang.T("guest", name)
+	}
+	return name
+}
+
+// One JSON object per line, with a "type" field, for scripts and log collectors
+type jsonRenderer struct {
+	w io.Writer
+}
+
+func (r *jsonRenderer) encode(v any) {
+	if err := json.NewEncoder(r.w).Encode(v); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error writing output: %v\n", err)
+	}
+}
+
+type jsonPick struct {
+	Type string `json:"type"`
+	picker.PickResult
+	OnDeck         []string `json:"onDeck,omitempty"`
+	Round          int      `json:"round"`
+	Position       int      `json:"position"`
+	Previous       string   `json:"previous,omitempty"`
+	ElapsedSeconds int      `json:"elapsedSeconds,omitempty"`
+}
+
+type jsonMember struct {
+	Type string `json:"type"`
+	picker.MemberResult
+	OnDeck []string `json:"onDeck,omitempty"`
+}
+
+func (r *jsonRenderer) Welcome(data welcomeData) {
+	r.encode(map[string]any{
+		"type":        "welcome",
+		"teamFile":    data.TeamFile,
+		"teamMembers": data.TeamMembers,
+		"stateFile":   data.StateFile,
+		"historyFile": data.HistoryFile,
+	})
+}
+
+func (r *jsonRenderer) Pick(data pickData) {
+	r.encode(jsonPick{
+		Type:           picker.EventPick,
+		PickResult:     data.result(),
+		OnDeck:         data.OnDeck,
+		Round:          data.Round,
+		Position:       data.Position,
+		Previous:       data.Previous,
+		ElapsedSeconds: int(data.Elapsed.Seconds()),
+	})
+}
+
+func (r *jsonRenderer) Skip(result picker.MemberResult, onDeck []string) {
+	r.encode(jsonMember{Type: picker.EventSkip, MemberResult: result, OnDeck: onDeck})
+}
+
+func (r *jsonRenderer) Absent(result picker.MemberResult) {
+	r.encode(jsonMember{Type: picker.EventAbsent, MemberResult: result})
+}
+
+func (r *jsonRenderer) Undo(result picker.MemberResult, onDeck []string) {
+	r.encode(jsonMember{Type: picker.EventUndo, MemberResult: result, OnDeck: onDeck})
+}
+
+func (r *jsonRenderer) GuestAdded(name string) {
+	r.encode(map[string]string{"type": "guest", "name": name})
+}
+
+func (r *jsonRenderer) Reset(teamMembers int) {
+	r.encode(map[string]any{"type": picker.EventReset, "teamMembers": teamMembers})
+}
+
+func (r *jsonRenderer) Status(status picker.Status) {
+	r.encode(struct {
+		Type string `json:"type"`
+		picker.Status
+	}{Type: "status", Status: status})
+}
+
+func (r *jsonRenderer) Candidates(remaining, guests []string) {
+	r.encode(struct {
+		Type      string   `json:"type"`
+		Remaining []string `json:"remaining"`
+		Guests    []string `json:"guests,omitempty"`
+	}{Type: "candidates", Remaining: remaining, Guests: guests})
+}
+
+func (r *jsonRenderer) Help() {
+	type command struct {
+		Key         string `json:"key,omitempty"`
+		Usage       string `json:"usage"`
+		Description string `json:"description"`
+	}
+	var commands []command
+	for _, k := range helpKeys {
+		commands = append(commands, command{Key: k.key, Usage: k.command, Description: english.T(k.description)})
+	}
+	for _, c := range helpCommands {
+		commands = append(commands, command{Usage: c.usage, Description: english.T(c.description)})
+	}
+	r.encode(map[string]any{"type": "help", "commands": commands})
+}
+
+func (r *jsonRenderer) Error(action string, err error) {
+	r.encode(map[string]string{"type": "error", "action": action, "error": err.Error()})
+}
+
+// Markdown, e.g. to paste the outcome in a chat or a meeting note
+type markdownRenderer struct {
+	w     io.Writer
+	emoji bool
+	lang  *language
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "#", `\#`, "<", `\<`, ">", `\>`, "|", `\|`)
+
+// Name in bold, escaped so that it renders as typed
+func markdownName(name string) string {
+	return "**" + markdownEscaper.Replace(name) + "**"
+}
+
+func (r *markdownRenderer) Welcome(data welcomeData) {
+	_, _ = fmt.Fprintln(r.w, "## Daily Scrum Picker")
+	_, _ = fmt.Fprintln(r.w)
+	members := r.lang.T("members", data.TeamMembers)
+	if data.Stdin {
+		_, _ = fmt.Fprintf(r.w, "- %s (%s)\n", r.lang.T("welcome.stdin"), members)
+	} else {
+		_, _ = fmt.Fprintf(r.w, "- %s (%s)\n", r.lang.T("welcome.teamFile", "`"+data.TeamFile+"`"), members)
+	}
+	_, _ = fmt.Fprintf(r.w, "- %s\n", r.lang.T("welcome.stateFile", "`"+data.StateFile+"`"))
+	_, _ = fmt.Fprintf(r.w, "- %s\n", r.lang.T("welcome.historyFile", "`"+data.HistoryFile+"`"))
+}
+
+func (r *markdownRenderer) Pick(data pickData) {
+	result, onDeck := data.result(), data.OnDeck
+	if result.NewRound {
+		_, _ = fmt.Fprintf(r.w, "_%s_\n", r.lang.T("newRound"))
+		_, _ = fmt.Fprintln(r.w)
+	}
+	if result.Action == picker.ActionManualPick {
+		_, _ = fmt.Fprintf(r.w, "%s%s %s\n", icon(r.emoji, "🎯 "), r.lang.T("pick", markdownName(result.Name)), r.lang.T("manualPick"))
+	} else {
+		_, _ = fmt.Fprintln(r.w, icon(r.emoji, "🎯 ")+r.lang.T("pick", markdownName(result.Name)))
+	}
+	r.remainingCount(result.Remaining)
+	r.onDeck(onDeck)
+}
+
+func (r *markdownRenderer) Skip(result picker.MemberResult, onDeck []string) {
+	_, _ = fmt.Fprintln(r.w, icon(r.emoji, "⏭️ ")+r.lang.T("skipped", markdownName(result.Name)))
+	r.onDeck(onDeck)
+}
+
+func (r *markdownRenderer) Absent(result picker.MemberResult) {
+	_, _ = fmt.Fprintln(r.w, r.lang.T("absent", markdownName(result.Name)))
+	r.remainingCount(result.Remaining)
+}
+
+func (r *markdownRenderer) Undo(result picker.MemberResult, onDeck []string) {
+	_, _ = fmt.Fprintln(r.w, icon(r.emoji, "↩️ ")+r.lang.T(undoMessage(result.Action), markdownName(result.Name)))
+	r.onDeck(onDeck)
+}
+
+func (r *markdownRenderer) GuestAdded(name string) {
+	_, _ = fmt.Fprintln(r.w, icon(r.emoji, "👋 ")+r.lang.T("guestAdded", markdownName(name)))
+}
+
+func (r *markdownRenderer) Reset(teamMembers int) {
+	_, _ = fmt.Fprintln(r.w, icon(r.emoji, "✅ ")+r.lang.T("reset", teamMembers))
+}
+
+func (r *markdownRenderer) Status(status picker.Status) {
+	_, _ = fmt.Fprintf(r.w, "### %s%s\n", icon(r.emoji, "📊 "), r.lang.T("status.title"))
+	_, _ = fmt.Fprintln(r.w)
+	_, _ = fmt.Fprintf(r.w, "- %s\n", r.lang.T("status.total", status.TeamMembers))
+	_, _ = fmt.Fprintf(r.w, "- %s\n", r.lang.T("status.remaining", len(status.Remaining)))
+	_, _ = fmt.Fprintln(r.w)
+	if len(status.Remaining) > 0 {
+		_, _ = fmt.Fprintln(r.w, r.lang.T("status.stillToPick"))
+		_, _ = fmt.Fprintln(r.w)
+		r.Candidates(status.Remaining, status.Guests)
+	} else {
+		_, _ = fmt.Fprintln(r.w, r.lang.T("status.everyonePicked")+".")
+	}
+}
+
+func (r *markdownRenderer) Candidates(remaining, guests []string) {
+	for i, name := range remaining {
+		_, _ = fmt.Fprintf(r.w, "%d. %s\n", i+1, markdownEscaper.Replace(guestLabel(r.lang, name, guests)))
+	}
+}
+
+func (r *markdownRenderer) Help() {
+	_, _ = fmt.Fprintf(r.w, "### %s%s\n", icon(r.emoji, "📋 "), r.lang.T("help.title"))
+	_, _ = fmt.Fprintln(r.w)
+	_, _ = fmt.Fprintf(r.w, "| %s | %s | %s |\n", r.lang.T("help.key"), r.lang.T("help.command"), r.lang.T("help.description"))
+	_, _ = fmt.Fprintln(r.w, "| --- | --- | --- |")
+	for _, k := range helpKeys {
+		_, _ = fmt.Fprintf(r.w, "| `%s` | `%s` | %s |\n", k.key, k.command, r.lang.T(k.description))
+	}
+	_, _ = fmt.Fprintln(r.w)
+	_, _ = fmt.Fprintln(r.w, r.lang.T("help.typing"))
+	_, _ = fmt.Fprintln(r.w)
+	for _, c := range helpCommands {
+		_, _ = fmt.Fprintf(r.w, "- `%s`: %s\n", c.usage, r.lang.T(c.description))
+	}
+}
+
+func (r *markdownRenderer) Error(action string, err error) {
+	text := markdownEscaper.Replace(r.lang.cannot(action, err))
+	_, _ = fmt.Fprintf(r.w, "**%s**\n", text)
+}
+
+func (r *markdownRenderer) remainingCount(remaining []string) {
+	_, _ = fmt.Fprintln(r.w)
+	if len(remaining) > 0 {
+		_, _ = fmt.Fprintf(r.w, "_%s_\n", r.lang.T("remaining", len(remaining)))
+	} else {
+		_, _ = fmt.Fprintf(r.w, "_%s_\n", r.lang.T("lastPerson"))
+	}
+}
+
+func (r *markdownRenderer) onDeck(onDeck []string) {
+	if len(onDeck) == 0 {
+		return
+	}
+	names := make([]string, len(onDeck))
+	for i, name := range onDeck {
+		names[i] = markdownEscaper.Replace(name)
+	}
+	_, _ = fmt.Fprintln(r.w)
+	_, _ = fmt.Fprintln(r.w, r.lang.T("onDeck", strings.Join(names, ", ")))
+}
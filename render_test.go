@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rm3l/daily-scrum-picker/picker"
+)
+
+func TestNewRenderer(t *testing.T) {
+	for _, output := range []string{OutputText, OutputJSON, OutputMarkdown} {
+		if _, err := newRenderer(&bytes.Buffer{}, renderOptions{output: output}); err != nil {
+			t.Errorf("newRenderer(%q) failed: %v", output, err)
+		}
+	}
+	if _, err := newRenderer(&bytes.Buffer{}, renderOptions{output: "html"}); err == nil {
+		t.Error("Expected an error for an unknown output format")
+	}
+	if _, err := newRenderer(&bytes.Buffer{}, renderOptions{color: "sometimes"}); err == nil {
+		t.Error("Expected an error for an unknown color mode")
+	}
+
+	var buf bytes.Buffer
+	r, _ := newRenderer(&buf, renderOptions{color: ColorModeNever, noEmoji: true})
+	r.Reset(3)
+	if expected := "State reset! All 3 team members are available for selection.\n"; buf.String() != expected {
+		t.Errorf("Expected %q without colors nor emojis, got %q", expected, buf.String())
+	}
+}
+
+func TestUseColor(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		env      map[string]string
+		expected bool
+	}{
+		{name: "not a terminal", mode: ColorModeAuto},
+		{name: "always", mode: ColorModeAlways, env: map[string]string{"NO_COLOR": "1"}, expected: true},
+		{name: "never", mode: ColorModeNever, env: map[string]string{"CLICOLOR_FORCE": "1"}},
+		{name: "forced", mode: ColorModeAuto, env: map[string]string{"CLICOLOR_FORCE": "1", "TERM": "dumb"}, expected: true},
+		{name: "force disabled", mode: ColorModeAuto, env: map[string]string{"CLICOLOR_FORCE": "0"}},
+		{name: "NO_COLOR wins over CLICOLOR_FORCE", mode: ColorModeAuto, env: map[string]string{"NO_COLOR": "1", "CLICOLOR_FORCE": "1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"NO_COLOR", "CLICOLOR_FORCE", "TERM"} {
+				t.Setenv(key, tt.env[key])
+			}
+			got, err := useColor(tt.mode, &bytes.Buffer{})
+			if err != nil {
+				t.Fatalf("useColor failed: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected colors: %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRenderers(t *testing.T) {
+	pick := pickData{Name: "Bob_Smith", Action: picker.ActionManualPick, Manual: true, Remaining: []string{"Alice", "Carol"}, OnDeck: []string{"Alice"}}
+	status := picker.Status{TeamMembers: 2, Remaining: []string{"Alice", "Carol"}, Guests: []string{"Carol"}}
+
+	tests := []struct {
+		output   string
+		color    string
+		render   func(r Renderer)
+		expected []string
+	}{
+		{
+			output:   OutputText,
+			render:   func(r Renderer) { r.Pick(pick) },
+			expected: []string{"🎯 Next is... Bob_Smith (manual pick)\n", "(2 people remaining in this round)\n", "On deck: Alice\n"},
+		},
+		{
+			output:   OutputText,
+			color:    ColorModeAlways,
+			render:   func(r Renderer) { r.Pick(pickData{Name: "Bob_Smith", Remaining: []string{"Alice"}}) },
+			expected: []string{"Next is... \033[1;34mBob_Smith\033[0m\n"},
+		},
+		{
+			output:   OutputText,
+			render:   func(r Renderer) { r.Status(status) },
+			expected: []string{"Total team members: 2", "   1. Alice\n", "   2. Carol (guest)\n"},
+		},
+		{
+			output:   OutputText,
+			render:   func(r Renderer) { r.Error("undo", picker.ErrNothingToUndo) },
+			expected: []string{"Cannot undo: nothing to undo\n"},
+		},
+		{
+			output:   OutputMarkdown,
+			render:   func(r Renderer) { r.Pick(pick) },
+			expected: []string{"🎯 Next is... **Bob\\_Smith** (manual pick)\n", "_2 people remaining in this round_\n", "On deck: Alice\n"},
+		},
+		{
+			output:   OutputMarkdown,
+			render:   func(r Renderer) { r.Status(status) },
+			expected: []string{"### 📊 Status\n", "- Remaining this round: 2\n", "2. Carol (guest)\n"},
+		},
+		{
+			output:   OutputMarkdown,
+			render:   func(r Renderer) { r.Help() },
+			expected: []string{"| `p` | `pick` | Pick the next person for daily scrum |\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.output+tt.color, func(t *testing.T) {
+			var buf bytes.Buffer
+			opts := renderOptions{output: tt.output, color: tt.color}
+			if opts.color == "" {
+				opts.color = ColorModeNever
+			}
+			r, err := newRenderer(&buf, opts)
+			if err != nil {
+				t.Fatalf("newRenderer failed: %v", err)
+			}
+			tt.render(r)
+			for _, expected := range tt.expected {
+				if !strings.Contains(buf.String(), expected) {
+					t.Errorf("Expected output to contain %q, got:\n%s", expected, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestJSONRenderer(t *testing.T) {
+	var buf bytes.Buffer
+	r := &jsonRenderer{w: &buf}
+	r.Pick(pickData{Name: "Bob", Action: picker.ActionRandomPick, Remaining: []string{"Alice"}, OnDeck: []string{"Alice"}, Round: 1, Position: 2})
+	r.Error("skip", errors.New("no such member"))
+	r.Status(picker.Status{TeamMembers: 2, Remaining: []string{"Alice"}})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected one JSON object per line, got:\n%s", buf.String())
+	}
+	var objects []map[string]any
+	for _, line := range lines {
+		var object map[string]any
+		if err := json.Unmarshal([]byte(line), &object); err != nil {
+			t.Fatalf("Invalid JSON %q: %v", line, err)
+		}
+		objects = append(objects, object)
+	}
+
+	if objects[0]["type"] != picker.EventPick || objects[0]["name"] != "Bob" || objects[0]["onDeck"] == nil || objects[0]["position"] != float64(2) {
+		t.Errorf("Unexpected pick: %v", objects[0])
+	}
+	if objects[1]["type"] != "error" || objects[1]["action"] != "skip" || objects[1]["error"] != "no such member" {
+		t.Errorf("Unexpected error: %v", objects[1])
+	}
+	if objects[2]["type"] != "status" || objects[2]["teamMembers"] != float64(2) {
+		t.Errorf("Unexpected status: %v", objects[2])
+	}
+}
+
+func TestAccessibleRenderer(t *testing.T) {
+	var buf bytes.Buffer
+	r, err := newRenderer(&buf, renderOptions{color: ColorModeAlways, accessible: true})
+	if err != nil {
+		t.Fatalf("newRenderer failed: %v", err)
+	}
+	r.Pick(pickData{Name: "Bob", Remaining: []string{"Alice"}, OnDeck: []string{"Alice"}})
+	r.Skip(picker.MemberResult{Name: "Alice", Remaining: []string{"Carol", "Alice"}}, nil)
+	r.Status(picker.Status{TeamMembers: 3, Remaining: []string{"Carol", "Alice"}, Guests: []string{"Carol"}})
+
+	expected := `Next speaker: Bob.
+1 person remaining in this round.
+On deck: Alice.
+Skipped Alice, moved to the end of this round.
+2 people remaining in this round.
+Status: Total team members: 3. Remaining this round: 2.
+Still to pick:
+1. Carol (guest).
+2. Alice.
+`
+	if buf.String() != expected {
+		t.Errorf("Expected plain sentences:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
@@ -0,0 +1,325 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/rm3l/daily-scrum-picker/picker"
+)
+
+// Room IDs end up in URLs and file names
+var roomIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+var (
+	errInvalidRoomID  = errors.New("invalid room ID: use lowercase letters, digits, '-' and '_'")
+	errNoMembersGiven = errors.New("no team members given")
+	errRoomExists     = errors.New("room already exists")
+	errRoomIDClash    = errors.New("room ID would share its settings with another room")
+	errNoSuchRoom     = errors.New("no such room")
+)
+
+// Independent stand-ups hosted by one server, each with its own team, state
+// and history, keyed by team ID
+type hub struct {
+	mu    sync.Mutex
+	rooms map[string]*room
+	// Where rooms created through the admin API are saved as team files, if set
+	teamsDir string
+	// Where the state and history of each room are kept
+	dataDir string
+	// Lifetime of the rooms' timers
+	ctx context.Context
+	// Who may create rooms, and who may list them
+	admin access
+	lobby access
+	// Notifications set up for each room
+	config config
+	// Language of the rooms' chat messages
+	lang *language
+	// Stand-up metrics of all rooms, and who may read them: open to everyone
+	// unless a metrics token is set
+	metrics       *metrics
+	metricsAccess access
+}
+
+type room struct {
+	*server
+	id      string
+	handler http.Handler
+}
+
+// Summary of a room, as listed by the admin API
+type roomInfo struct {
+	ID          string `json:"id"`
+	TeamMembers int    `json:"teamMembers"`
+}
+
+// Body of POST /api/rooms, e.g. {"id": "payments", "members": ["Alice", "Bob"]}
+type createRoomRequest struct {
+	ID      string   `json:"id"`
+	Members []string `json:"members"`
+}
+
+func newHub(ctx context.Context, teamsDir, dataDir string) (*hub, error) {
+	if err := os.MkdirAll(dataDir, 0o755); err != nil {
+		return nil, err
+	}
+	return &hub{
+		rooms:    make(map[string]*room),
+		teamsDir: teamsDir,
+		dataDir:  dataDir,
+		ctx:      ctx,
+		lang:     english,
+		metrics:  newMetrics(),
+	}, nil
+}
+
+// Host the session as a room; its timer runs until the hub's context is done
+func (h *hub) addRoom(id string, s *session) (*room, error) {
+	if !roomIDPattern.MatchString(id) {
+		return nil, fmt.Errorf("%w: '%s'", errInvalidRoomID, id)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err := h.checkRoomID(id); err != nil {
+		return nil, err
+	}
+
+	srv := newServer(s)
+	srv.access = roomAccess(id)
+	srv.slack = newSlackVerifier(roomSetting(id, "SLACK_SIGNING_SECRET"))
+	r := &room{server: srv, id: id, handler: srv.routes()}
+	h.rooms[id] = r
+	h.metrics.track(id, s)
+	go srv.runTicker(h.ctx)
+	return r, nil
+}
+
+// Refuse an ID in use, or one that would share the tokens and secrets of
+// another room (e.g. FACILITATOR_TOKEN_A_B for both a-b and a_b). Called with
+// the lock held.
+func (h *hub) checkRoomID(id string) error {
+	if _, ok := h.rooms[id]; ok {
+		return fmt.Errorf("%w: '%s'", errRoomExists, id)
+	}
+	for other := range h.rooms {
+		if roomSettingSuffix(other) == roomSettingSuffix(id) {
+			return fmt.Errorf("%w: '%s' and '%s'", errRoomIDClash, id, other)
+		}
+	}
+	return nil
+}
+
+func (h *hub) newRoomSession(id string, members []string) *session {
+	s := &session{
+		Picker: picker.New(members,
+			filepath.Join(h.dataDir, id+"-remaining.txt"),
+			filepath.Join(h.dataDir, id+"-history.txt")),
+		lang: h.lang,
+	}
+	s.notifyWebhooks(h.config, id)
+	s.runHooks(h.config, id)
+	return s
+}
+
+// Create a room for each team file (e.g. payments.txt) in the teams directory
+func (h *hub) loadTeamsDir() error {
+	entries, err := os.ReadDir(h.teamsDir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".txt" {
+			continue
+		}
+		id := strings.TrimSuffix(entry.Name(), ".txt")
+		members, err := loadTeamMembers(filepath.Join(h.teamsDir, entry.Name()))
+		if err != nil {
+			return err
+		}
+		if len(members) == 0 {
+			log.Printf("Warning: skipping '%s': no team members found", entry.Name())
+			continue
+		}
+		if _, err := h.addRoom(id, h.newRoomSession(id, members)); err != nil {
+			log.Printf("Warning: skipping '%s': %v", entry.Name(), err)
+		}
+	}
+	return nil
+}
+
+// Create a room from the admin API, saving its team file when there is a
+// teams directory so that it is loaded again on restart
+func (h *hub) createRoom(id string, members []string) (*room, error) {
+	var cleaned []string
+	for _, name := range members {
+		if name = strings.TrimSpace(name); name != "" && !slices.Contains(cleaned, name) {
+			cleaned = append(cleaned, name)
+		}
+	}
+	if !roomIDPattern.MatchString(id) {
+		return nil, fmt.Errorf("%w: '%s'", errInvalidRoomID, id)
+	}
+	if len(cleaned) == 0 {
+		return nil, errNoMembersGiven
+	}
+	h.mu.Lock()
+	err := h.checkRoomID(id)
+	h.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	if h.teamsDir != "" {
+		if err := writeTeamFile(filepath.Join(h.teamsDir, id+".txt"), cleaned); err != nil {
+			if errors.Is(err, os.ErrExist) {
+				return nil, fmt.Errorf("%w: '%s'", errRoomExists, id)
+			}
+			return nil, err
+		}
+	}
+	return h.addRoom(id, h.newRoomSession(id, cleaned))
+}
+
+// Write a new team file, without overwriting an existing one
+func writeTeamFile(teamFile string, members []string) error {
+	file, err := os.OpenFile(teamFile, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			fmt.Printf("Warning: failed to close file: %v\n", err)
+		}
+	}()
+
+	_, err = file.WriteString(strings.Join(members, "\n") + "\n")
+	return err
+}
+
+func (h *hub) room(id string) (*room, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	r, ok := h.rooms[id]
+	return r, ok
+}
+
+func (h *hub) list() []roomInfo {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	rooms := make([]roomInfo, 0, len(h.rooms))
+	for id, r := range h.rooms {
+		rooms = append(rooms, roomInfo{ID: id, TeamMembers: len(r.session.TeamMembers())})
+	}
+	slices.SortFunc(rooms, func(a, b roomInfo) int { return strings.Compare(a.ID, b.ID) })
+	return rooms
+}
+
+// Disconnect the live clients of every room, e.g. on shutdown
+func (h *hub) closeAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, r := range h.rooms {
+		r.events.closeAll()
+	}
+}
+
+// Close the session of every room, once the server no longer handles requests
+func (h *hub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, r := range h.rooms {
+		r.session.close()
+	}
+}
+
+// Rooms are served under /rooms/{room}/. A default room, if any, is also
+// served at the root, as with a single team.
+func (h *hub) routes(defaultRoom *room) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/rooms", h.lobby.require(roleViewer, h.handleListRooms))
+	mux.HandleFunc("GET /metrics", h.metricsAccess.require(roleViewer, h.metrics.handleMetrics))
+	mux.HandleFunc("POST /api/rooms", h.admin.require(roleFacilitator, h.handleCreateRoom))
+	mux.HandleFunc("/rooms/{room}/", h.handleRoom)
+	if defaultRoom != nil {
+		mux.Handle("/", defaultRoom.handler)
+	} else {
+		mux.HandleFunc("GET /{$}", h.lobby.require(roleViewer, h.handleRoomsPage))
+	}
+	return mux
+}
+
+func (h *hub) handleRoom(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("room")
+	rm, ok := h.room(id)
+	if !ok {
+		writeError(w, fmt.Errorf("%w: '%s'", errNoSuchRoom, id))
+		return
+	}
+	http.StripPrefix("/rooms/"+id, rm.handler).ServeHTTP(w, r)
+}
+
+func (h *hub) handleListRooms(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string][]roomInfo{"rooms": h.list()})
+}
+
+func (h *hub) handleCreateRoom(w http.ResponseWriter, r *http.Request) {
+	var req createRoomRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	rm, err := h.createRoom(strings.TrimSpace(req.ID), req.Members)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	log.Printf("Created room '%s' with %d team members", rm.id, len(rm.session.TeamMembers()))
+	writeJSON(w, http.StatusCreated, roomInfo{ID: rm.id, TeamMembers: len(rm.session.TeamMembers())})
+}
+
+var roomsPage = template.Must(template.New("rooms").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Daily Scrum Picker</title>
+</head>
+<body>
+  <main>
+    <section>
+      <h1>🎲 Daily Scrum Picker</h1>
+      <p>Pick your team to join its stand-up.</p>
+      <ul>
+        {{- range .}}
+        <li><a href="rooms/{{.ID}}/">{{.ID}}</a> ({{.TeamMembers}} members)</li>
+        {{- end}}
+      </ul>
+    </section>
+  </main>
+</body>
+</html>
+`))
+
+func (h *hub) handleRoomsPage(w http.ResponseWriter, r *http.Request) {
+	rooms := h.list()
+	if len(rooms) == 0 {
+		http.Error(w, "No rooms yet: add team files to the teams directory or create one with POST /api/rooms", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := roomsPage.Execute(w, rooms); err != nil {
+		log.Printf("Error rendering rooms page: %v", err)
+	}
+}
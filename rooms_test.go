@@ -0,0 +1,172 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/rm3l/daily-scrum-picker/picker"
+)
+
+func newTestHub(t *testing.T, teams map[string]string) (*hub, *httptest.Server) {
+	t.Helper()
+	teamsDir := t.TempDir()
+	for name, content := range teams {
+		if err := os.WriteFile(filepath.Join(teamsDir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write team file: %v", err)
+		}
+	}
+
+	h, err := newHub(t.Context(), teamsDir, t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create hub: %v", err)
+	}
+	if err := h.loadTeamsDir(); err != nil {
+		t.Fatalf("Failed to load teams: %v", err)
+	}
+	ts := httptest.NewServer(h.routes(nil))
+	t.Cleanup(ts.Close)
+	return h, ts
+}
+
+func TestHub_LoadTeamsDir(t *testing.T) {
+	h, _ := newTestHub(t, map[string]string{
+		"payments.txt": "Alice\nBob\n",
+		"search.txt":   "# Search squad\nCharlie\n",
+		"empty.txt":    "# Nobody yet\n",
+		"Bad Name.txt": "Diana\n",
+		"notes.md":     "Not a team\n",
+	})
+
+	expected := []roomInfo{{ID: "payments", TeamMembers: 2}, {ID: "search", TeamMembers: 1}}
+	if rooms := h.list(); !slices.Equal(rooms, expected) {
+		t.Errorf("Expected rooms %v, got %v", expected, rooms)
+	}
+}
+
+func TestHub_RoomsAreIndependent(t *testing.T) {
+	_, ts := newTestHub(t, map[string]string{
+		"payments.txt": "Alice\n",
+		"search.txt":   "Charlie\nDiana\n",
+	})
+
+	var pick picker.PickResult
+	if status := doJSON(t, http.MethodPost, ts.URL+"/rooms/payments/api/pick", "", &pick); status != http.StatusOK {
+		t.Fatalf("Expected 200 for pick, got %d", status)
+	}
+	if pick.Name != "Alice" {
+		t.Errorf("Expected Alice to be picked in payments, got %+v", pick)
+	}
+
+	var status picker.Status
+	doJSON(t, http.MethodGet, ts.URL+"/rooms/search/api/status", "", &status)
+	if status.TeamMembers != 2 || len(status.Remaining) != 2 {
+		t.Errorf("Expected the search room to be untouched, got %+v", status)
+	}
+
+	var history map[string][]picker.HistoryEntry
+	doJSON(t, http.MethodGet, ts.URL+"/rooms/search/api/history", "", &history)
+	if len(history["entries"]) != 0 {
+		t.Errorf("Expected no history in the search room, got %v", history["entries"])
+	}
+
+	var resp errorResponse
+	if code := doJSON(t, http.MethodGet, ts.URL+"/rooms/unknown/api/status", "", &resp); code != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown room, got %d", code)
+	}
+}
+
+func TestHub_CreateRoom(t *testing.T) {
+	h, ts := newTestHub(t, map[string]string{"payments.txt": "Alice\n"})
+
+	var info roomInfo
+	body := `{"id": "mobile", "members": ["Eve", " Frank ", "", "Eve"]}`
+	if status := doJSON(t, http.MethodPost, ts.URL+"/api/rooms", body, &info); status != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d", status)
+	}
+	if info != (roomInfo{ID: "mobile", TeamMembers: 2}) {
+		t.Errorf("Unexpected room %+v", info)
+	}
+
+	// Saved in the teams directory, to be loaded again on restart
+	members, err := loadTeamMembers(filepath.Join(h.teamsDir, "mobile.txt"))
+	if err != nil || !slices.Equal(members, []string{"Eve", "Frank"}) {
+		t.Errorf("Expected the team file to be saved, got %v (err=%v)", members, err)
+	}
+
+	var rooms map[string][]roomInfo
+	doJSON(t, http.MethodGet, ts.URL+"/api/rooms", "", &rooms)
+	if len(rooms["rooms"]) != 2 {
+		t.Errorf("Expected 2 rooms, got %v", rooms["rooms"])
+	}
+
+	tests := []struct {
+		name     string
+		body     string
+		expected int
+	}{
+		{name: "existing room", body: `{"id": "payments", "members": ["Zoe"]}`, expected: http.StatusConflict},
+		{name: "invalid ID", body: `{"id": "../etc", "members": ["Zoe"]}`, expected: http.StatusBadRequest},
+		{name: "no members", body: `{"id": "empty", "members": [" "]}`, expected: http.StatusBadRequest},
+		{name: "no body", expected: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp errorResponse
+			if status := doJSON(t, http.MethodPost, ts.URL+"/api/rooms", tt.body, &resp); status != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, status)
+			}
+			if resp.Error == "" {
+				t.Error("Expected an error message in the response")
+			}
+		})
+	}
+}
+
+func TestHub_RoomIDsSharingSettings(t *testing.T) {
+	h, ts := newTestHub(t, map[string]string{"team-a.txt": "Alice\n", "team_a.txt": "Bob\n"})
+	if rooms := h.list(); len(rooms) != 1 {
+		t.Errorf("Expected only one of the rooms sharing FACILITATOR_TOKEN_TEAM_A, got %v", rooms)
+	}
+
+	if status := doJSON(t, http.MethodPost, ts.URL+"/api/rooms", `{"id": "payments-eu", "members": ["Yves"]}`, nil); status != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d", status)
+	}
+	var resp errorResponse
+	if status := doJSON(t, http.MethodPost, ts.URL+"/api/rooms", `{"id": "payments_eu", "members": ["Zoe"]}`, &resp); status != http.StatusConflict {
+		t.Errorf("Expected 409, got %d (%s)", status, resp.Error)
+	}
+	if _, err := os.Stat(filepath.Join(h.teamsDir, "payments_eu.txt")); !os.IsNotExist(err) {
+		t.Errorf("Expected no team file for the refused room, got %v", err)
+	}
+}
+
+func TestHub_DefaultRoomServedAtRoot(t *testing.T) {
+	h, err := newHub(t.Context(), "", t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create hub: %v", err)
+	}
+	s := newTestSession(t, "Alice", "Bob")
+	setTestRound(t, s, s.TeamMembers()...)
+	defaultRoom, err := h.addRoom("default", s)
+	if err != nil {
+		t.Fatalf("Failed to add room: %v", err)
+	}
+	ts := httptest.NewServer(h.routes(defaultRoom))
+	t.Cleanup(ts.Close)
+
+	var pick picker.PickResult
+	doJSON(t, http.MethodPost, ts.URL+"/api/pick", "", &pick)
+	var status picker.Status
+	doJSON(t, http.MethodGet, ts.URL+"/rooms/default/api/status", "", &status)
+	if pick.Name != "Alice" || !slices.Equal(status.Remaining, []string{"Bob"}) {
+		t.Errorf("Expected the root and the default room to share the same round, got %+v and %+v", pick, status)
+	}
+
+	if code := doJSON(t, http.MethodGet, ts.URL+"/api/pick", "", nil); code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for a wrong method, got %d", code)
+	}
+}
@@ -0,0 +1,346 @@
+package main
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rm3l/daily-scrum-picker/picker"
+)
+
+var (
+	serveAddrFlag string
+	teamsDirFlag  string
+	dataDirFlag   string
+	grpcAddrFlag  string
+)
+
+// Static web UI, served at the root of the server
+//
+//go:embed web
+var webFiles embed.FS
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the picker over HTTP, so everyone on the call sees the same picks",
+	Args:  cobra.NoArgs,
+	RunE:  runServe,
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddrFlag, "addr", ":8080", "Address to listen on")
+	serveCmd.Flags().StringVar(&teamsDirFlag, "teams-dir", "", "Directory of team files (e.g. payments.txt), each served as its own room")
+	serveCmd.Flags().StringVar(&dataDirFlag, "data-dir", filepath.Join(os.TempDir(), "daily-scrum-picker"), "Directory for the state and history of each room")
+	serveCmd.Flags().StringVar(&grpcAddrFlag, "grpc-addr", "", "Address to serve the gRPC API on (disabled by default)")
+	rootCmd.AddCommand(serveCmd)
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	h, err := newHub(ctx, teamsDirFlag, dataDirFlag)
+	if err != nil {
+		return err
+	}
+	h.admin, h.lobby = hubAccess()
+	if h.lang, err = selectLanguage(langFlag); err != nil {
+		return err
+	}
+	h.metricsAccess = access{facilitator: os.Getenv("METRICS_TOKEN"), viewer: os.Getenv("METRICS_TOKEN")}
+	h.config = loadConfigOrExit()
+	defer h.close()
+
+	// Without a teams directory, the team file is served at the root as
+	// before, and also as the "default" room
+	var defaultRoom *room
+	if teamsDirFlag != "" {
+		if err := h.loadTeamsDir(); err != nil {
+			return fmt.Errorf("loading teams directory: %w", err)
+		}
+	} else if defaultRoom, err = h.addRoom("default", loadSession(getTeamFile(teamFileFlag))); err != nil {
+		return err
+	}
+
+	httpServer := &http.Server{
+		Addr:              serveAddrFlag,
+		Handler:           h.routes(defaultRoom),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	// picker.Event streams never go idle, so end them for Shutdown to complete
+	httpServer.RegisterOnShutdown(h.closeAll)
+
+	errs := make(chan error, 2)
+	go func() {
+		for _, info := range h.list() {
+			log.Printf("Serving room '%s' (%d team members)", info.ID, info.TeamMembers)
+		}
+		log.Printf("Listening on %s", serveAddrFlag)
+		errs <- httpServer.ListenAndServe()
+	}()
+
+	if grpcAddrFlag != "" {
+		listener, err := net.Listen("tcp", grpcAddrFlag)
+		if err != nil {
+			return err
+		}
+		grpcServer := newGRPCServer(h)
+		// Stopped after the HTTP server, which ends the event streams
+		defer grpcServer.GracefulStop()
+		go func() {
+			log.Printf("Serving gRPC on %s", grpcAddrFlag)
+			errs <- grpcServer.Serve(listener)
+		}()
+	}
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Println("Shutting down...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return httpServer.Shutdown(shutdownCtx)
+}
+
+// HTTP front-end to a session. Requests are serialized, as they all read and
+// write the same state and history files.
+type server struct {
+	mu      sync.Mutex
+	session *session
+	access  access
+	slack   slackVerifier
+
+	// Live mode: connected clients and the current speaker's timer
+	events        *broadcaster
+	speaker       string
+	speakingSince time.Time
+	tickInterval  time.Duration
+}
+
+func newServer(s *session) *server {
+	srv := &server{
+		session:      s,
+		events:       newBroadcaster(),
+		slack:        newSlackVerifier(""),
+		tickInterval: time.Second,
+	}
+	s.Subscribe(srv.onEvent)
+	return srv
+}
+
+func (srv *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/role", srv.handleRole)
+	mux.HandleFunc("GET /api/status", srv.access.require(roleViewer, srv.handleStatus))
+	mux.HandleFunc("GET /api/history", srv.access.require(roleViewer, srv.handleHistory))
+	mux.HandleFunc("GET /api/events", srv.access.require(roleViewer, srv.handleEvents))
+	mux.HandleFunc("POST /api/pick", srv.access.require(roleFacilitator, srv.handlePick))
+	mux.HandleFunc("POST /api/skip", srv.access.require(roleFacilitator, srv.handleSkip))
+	mux.HandleFunc("POST /api/absent", srv.access.require(roleFacilitator, srv.handleAbsent))
+	mux.HandleFunc("POST /api/undo", srv.access.require(roleFacilitator, srv.handleUndo))
+	mux.HandleFunc("POST /api/reset", srv.access.require(roleFacilitator, srv.handleReset))
+	// Authenticated by its signature rather than by a token
+	mux.HandleFunc("POST /slack/command", srv.handleSlackCommand)
+
+	// Web UI files are registered one by one, so that the API keeps answering
+	// 404 and 405 for unknown paths and methods
+	web, err := fs.Sub(webFiles, "web")
+	if err != nil {
+		panic(err)
+	}
+	files, err := fs.ReadDir(web, ".")
+	if err != nil {
+		panic(err)
+	}
+	ui := http.FileServerFS(web)
+	mux.Handle("GET /{$}", ui)
+	for _, file := range files {
+		if file.Name() != "index.html" {
+			mux.Handle("GET /"+file.Name(), ui)
+		}
+	}
+	return mux
+}
+
+// Optional JSON body of the member endpoints, e.g. {"name": "Alice"}
+type memberRequest struct {
+	Name string `json:"name"`
+}
+
+func (srv *server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.writeStatus(w)
+}
+
+func (srv *server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	entries, err := srv.session.History()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if entries == nil {
+		entries = []picker.HistoryEntry{}
+	}
+	writeJSON(w, http.StatusOK, map[string][]picker.HistoryEntry{"entries": entries})
+}
+
+func (srv *server) handlePick(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeMemberRequest(w, r)
+	if !ok {
+		return
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	result, err := srv.session.Pick(req.Name)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (srv *server) handleSkip(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeMemberRequest(w, r)
+	if !ok {
+		return
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	result, err := srv.session.Skip(req.Name)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (srv *server) handleAbsent(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeMemberRequest(w, r)
+	if !ok {
+		return
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	result, err := srv.session.MarkAbsent(req.Name)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (srv *server) handleUndo(w http.ResponseWriter, r *http.Request) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	result, err := srv.session.Undo()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (srv *server) handleReset(w http.ResponseWriter, r *http.Request) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	if err := srv.session.Reset(); err != nil {
+		writeError(w, err)
+		return
+	}
+	srv.writeStatus(w)
+}
+
+// Must be called with srv.mu held
+func (srv *server) writeStatus(w http.ResponseWriter) {
+	status, err := srv.session.Status()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+// Decode the optional request body; an empty body means no name was given
+func decodeMemberRequest(w http.ResponseWriter, r *http.Request) (memberRequest, bool) {
+	var req memberRequest
+	return req, decodeJSON(w, r, &req)
+}
+
+// Decode a JSON request body into v, leaving it untouched if the body is
+// empty. A bad request is answered right away, in which case false is returned.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(v)
+	if err != nil && !errors.Is(err, io.EOF) {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: fmt.Sprintf("invalid request body: %v", err)})
+		return false
+	}
+	return true
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Map picker errors to HTTP status codes
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, picker.ErrNoName), errors.Is(err, errInvalidRoomID), errors.Is(err, errNoMembersGiven):
+		return http.StatusBadRequest
+	case errors.Is(err, errUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, errForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, picker.ErrNoSuchMember), errors.Is(err, errNoSuchRoom):
+		return http.StatusNotFound
+	case errors.Is(err, picker.ErrAmbiguousMember), errors.Is(err, picker.ErrNothingToUndo), errors.Is(err, picker.ErrNoMembers), errors.Is(err, errRoomExists), errors.Is(err, errRoomIDClash):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := errorStatus(err)
+	if status == http.StatusInternalServerError {
+		log.Printf("Error handling request: %v", err)
+	}
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Error writing response: %v", err)
+	}
+}
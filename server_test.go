@@ -0,0 +1,308 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rm3l/daily-scrum-picker/picker"
+)
+
+func newTestServer(t *testing.T, teamMembers ...string) *httptest.Server {
+	t.Helper()
+	s := newTestSession(t, teamMembers...)
+	setTestRound(t, s, teamMembers...)
+	ts := httptest.NewServer(newServer(s).routes())
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// Send a request and decode the JSON response into v, returning the status code
+func doJSON(t *testing.T, method, url, body string, v any) int {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request %s %s failed: %v", method, url, err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			t.Logf("Warning: failed to close response body: %v", err)
+		}
+	}()
+
+	if ct := resp.Header.Get("Content-Type"); resp.StatusCode != http.StatusMethodNotAllowed && ct != "application/json" {
+		t.Errorf("Expected JSON response, got Content-Type %q", ct)
+	}
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestServer_PickStatusUndoHistory(t *testing.T) {
+	ts := newTestServer(t, "Alice", "Bob", "Charlie")
+
+	var pick picker.PickResult
+	if status := doJSON(t, http.MethodPost, ts.URL+"/api/pick", "", &pick); status != http.StatusOK {
+		t.Fatalf("Expected 200 for pick, got %d", status)
+	}
+	if pick.Name != "Alice" || pick.Action != picker.ActionRandomPick || !slices.Equal(pick.Remaining, []string{"Bob", "Charlie"}) {
+		t.Errorf("Unexpected pick result %+v", pick)
+	}
+
+	if status := doJSON(t, http.MethodPost, ts.URL+"/api/pick", `{"name": "char"}`, &pick); status != http.StatusOK {
+		t.Fatalf("Expected 200 for manual pick, got %d", status)
+	}
+	if pick.Name != "Charlie" || pick.Action != picker.ActionManualPick {
+		t.Errorf("Unexpected manual pick result %+v", pick)
+	}
+
+	var history struct {
+		Entries []picker.HistoryEntry `json:"entries"`
+	}
+	if status := doJSON(t, http.MethodGet, ts.URL+"/api/history", "", &history); status != http.StatusOK {
+		t.Fatalf("Expected 200 for history, got %d", status)
+	}
+	if len(history.Entries) != 2 || history.Entries[1].Name != "Charlie" {
+		t.Errorf("Unexpected history %+v", history.Entries)
+	}
+
+	var undone picker.MemberResult
+	if status := doJSON(t, http.MethodPost, ts.URL+"/api/undo", "", &undone); status != http.StatusOK {
+		t.Fatalf("Expected 200 for undo, got %d", status)
+	}
+	if undone.Name != "Charlie" || !slices.Equal(undone.Remaining, []string{"Charlie", "Bob"}) {
+		t.Errorf("Unexpected undo result %+v", undone)
+	}
+
+	var status picker.Status
+	if code := doJSON(t, http.MethodGet, ts.URL+"/api/status", "", &status); code != http.StatusOK {
+		t.Fatalf("Expected 200 for status, got %d", code)
+	}
+	if status.TeamMembers != 3 || !slices.Equal(status.Remaining, []string{"Charlie", "Bob"}) {
+		t.Errorf("Unexpected status %+v", status)
+	}
+}
+
+func TestServer_SkipAbsentReset(t *testing.T) {
+	ts := newTestServer(t, "Alice", "Bob", "Charlie")
+
+	var result picker.MemberResult
+	if status := doJSON(t, http.MethodPost, ts.URL+"/api/skip", "", &result); status != http.StatusOK {
+		t.Fatalf("Expected 200 for skip, got %d", status)
+	}
+	if !slices.Equal(result.Remaining, []string{"Bob", "Charlie", "Alice"}) {
+		t.Errorf("Unexpected skip result %+v", result)
+	}
+
+	if status := doJSON(t, http.MethodPost, ts.URL+"/api/absent", `{"name": "2"}`, &result); status != http.StatusOK {
+		t.Fatalf("Expected 200 for absent, got %d", status)
+	}
+	if result.Name != "Charlie" || !slices.Equal(result.Remaining, []string{"Bob", "Alice"}) {
+		t.Errorf("Unexpected absent result %+v", result)
+	}
+
+	var status picker.Status
+	if code := doJSON(t, http.MethodPost, ts.URL+"/api/reset", "", &status); code != http.StatusOK {
+		t.Fatalf("Expected 200 for reset, got %d", code)
+	}
+	if len(status.Remaining) != 3 {
+		t.Errorf("Expected everyone back after reset, got %+v", status)
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	ts := newTestServer(t, "Alice", "Albert")
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		expected int
+	}{
+		{name: "unknown member", method: http.MethodPost, path: "/api/pick", body: `{"name": "Zoe"}`, expected: http.StatusNotFound},
+		{name: "ambiguous member", method: http.MethodPost, path: "/api/pick", body: `{"name": "Al"}`, expected: http.StatusConflict},
+		{name: "absent without name", method: http.MethodPost, path: "/api/absent", expected: http.StatusBadRequest},
+		{name: "invalid body", method: http.MethodPost, path: "/api/pick", body: `{`, expected: http.StatusBadRequest},
+		{name: "nothing to undo", method: http.MethodPost, path: "/api/undo", expected: http.StatusConflict},
+		{name: "wrong method", method: http.MethodGet, path: "/api/pick", expected: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp errorResponse
+			var v any = &resp
+			if tt.expected == http.StatusMethodNotAllowed {
+				v = nil
+			}
+			if status := doJSON(t, tt.method, ts.URL+tt.path, tt.body, v); status != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, status)
+			}
+			if v != nil && resp.Error == "" {
+				t.Error("Expected an error message in the response")
+			}
+		})
+	}
+}
+
+// Read Server-Sent Events until one of the given type arrives, returning its data
+func readSSE(t *testing.T, r *bufio.Reader, eventType string) string {
+	t.Helper()
+	var current string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Failed to read event stream waiting for %q: %v", eventType, err)
+		}
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			current = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: ") && current == eventType:
+			return strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func openEventStream(t *testing.T, url string) *bufio.Reader {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+"/api/events", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to open event stream: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := resp.Body.Close(); err != nil {
+			t.Logf("Warning: failed to close event stream: %v", err)
+		}
+	})
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Expected an event stream, got Content-Type %q", ct)
+	}
+	return bufio.NewReader(resp.Body)
+}
+
+func TestServer_EventsStreamStateAndChanges(t *testing.T) {
+	ts := newTestServer(t, "Alice", "Bob")
+	events := openEventStream(t, ts.URL)
+
+	var state liveState
+	if err := json.Unmarshal([]byte(readSSE(t, events, "state")), &state); err != nil {
+		t.Fatalf("Failed to decode state: %v", err)
+	}
+	if state.Speaker != "" || !slices.Equal(state.Remaining, []string{"Alice", "Bob"}) {
+		t.Errorf("Unexpected initial state %+v", state)
+	}
+
+	doJSON(t, http.MethodPost, ts.URL+"/api/pick", "", nil)
+	var update liveUpdate
+	if err := json.Unmarshal([]byte(readSSE(t, events, picker.EventPick)), &update); err != nil {
+		t.Fatalf("Failed to decode pick event: %v", err)
+	}
+	if update.Event.Name != "Alice" || update.State.Speaker != "Alice" || !slices.Equal(update.State.Remaining, []string{"Bob"}) {
+		t.Errorf("Unexpected pick event %+v", update)
+	}
+
+	doJSON(t, http.MethodPost, ts.URL+"/api/undo", "", nil)
+	var undo liveUpdate
+	if err := json.Unmarshal([]byte(readSSE(t, events, picker.EventUndo)), &undo); err != nil {
+		t.Fatalf("Failed to decode undo event: %v", err)
+	}
+	if undo.State.Speaker != "" || !slices.Equal(undo.State.Remaining, []string{"Alice", "Bob"}) {
+		t.Errorf("Expected undo to clear the speaker, got %+v", undo)
+	}
+
+	// A client (re)connecting gets the latest state right away
+	var resumed liveState
+	if err := json.Unmarshal([]byte(readSSE(t, openEventStream(t, ts.URL), "state")), &resumed); err != nil {
+		t.Fatalf("Failed to decode state: %v", err)
+	}
+	if !slices.Equal(resumed.Remaining, []string{"Alice", "Bob"}) {
+		t.Errorf("Unexpected state on reconnection %+v", resumed)
+	}
+}
+
+func TestServer_EventsTickWhileSomeoneSpeaks(t *testing.T) {
+	s := newTestSession(t, "Alice", "Bob")
+	setTestRound(t, s, s.TeamMembers()...)
+	srv := newServer(s)
+	srv.tickInterval = 10 * time.Millisecond
+	ts := httptest.NewServer(srv.routes())
+	t.Cleanup(ts.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go srv.runTicker(ctx)
+
+	events := openEventStream(t, ts.URL)
+	doJSON(t, http.MethodPost, ts.URL+"/api/pick", "", nil)
+
+	var tick liveTick
+	if err := json.Unmarshal([]byte(readSSE(t, events, "tick")), &tick); err != nil {
+		t.Fatalf("Failed to decode tick: %v", err)
+	}
+	if tick.Speaker != "Alice" {
+		t.Errorf("Expected Alice's timer to tick, got %+v", tick)
+	}
+}
+
+func TestServer_WebUI(t *testing.T) {
+	ts := newTestServer(t, "Alice", "Bob")
+
+	tests := []struct {
+		path        string
+		contentType string
+		contains    string
+	}{
+		{path: "/", contentType: "text/html", contains: `id="next"`},
+		{path: "/app.js", contentType: "text/javascript", contains: "api/events"},
+		{path: "/style.css", contentType: "text/css", contains: "#speaker"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			resp, err := http.Get(ts.URL + tt.path)
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			defer func() {
+				if err := resp.Body.Close(); err != nil {
+					t.Logf("Warning: failed to close response body: %v", err)
+				}
+			}()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response: %v", err)
+			}
+
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("Expected 200, got %d", resp.StatusCode)
+			}
+			if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, tt.contentType) {
+				t.Errorf("Expected Content-Type %s, got %q", tt.contentType, ct)
+			}
+			if !strings.Contains(string(body), tt.contains) {
+				t.Errorf("Expected %s to contain %q", tt.path, tt.contains)
+			}
+		})
+	}
+}
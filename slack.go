@@ -0,0 +1,235 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rm3l/daily-scrum-picker/picker"
+)
+
+// How old a Slack request may be, to prevent replaying captured requests
+const slackTimestampTolerance = 5 * time.Minute
+
+var (
+	errSlackSignature = errors.New("invalid Slack request signature")
+	errSlackTimestamp = errors.New("Slack request timestamp is missing or too old")
+)
+
+// Checks that requests come from Slack, see
+// https://api.slack.com/authentication/verifying-requests-from-slack
+type slackVerifier struct {
+	signingSecret string
+	tolerance     time.Duration
+	now           func() time.Time
+}
+
+func newSlackVerifier(signingSecret string) slackVerifier {
+	return slackVerifier{signingSecret: signingSecret, tolerance: slackTimestampTolerance, now: time.Now}
+}
+
+func (v slackVerifier) verify(header http.Header, body []byte) error {
+	timestamp := header.Get("X-Slack-Request-Timestamp")
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errSlackTimestamp
+	}
+	if age := v.now().Sub(time.Unix(seconds, 0)); age > v.tolerance || age < -v.tolerance {
+		return errSlackTimestamp
+	}
+
+	expected := slackSignature(v.signingSecret, timestamp, body)
+	if !hmac.Equal([]byte(header.Get("X-Slack-Signature")), []byte(expected)) {
+		return errSlackSignature
+	}
+	return nil
+}
+
+// Version 0 signature of a Slack request: an HMAC-SHA256 of the timestamp and body
+func slackSignature(signingSecret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Slack message sent back to the channel, see https://api.slack.com/messaging/composing
+type slackMessage struct {
+	// "in_channel" to show it to everyone, "ephemeral" to the caller only
+	ResponseType string       `json:"response_type"`
+	Text         string       `json:"text"`
+	Blocks       []slackBlock `json:"blocks,omitempty"`
+}
+
+type slackBlock struct {
+	Type     string       `json:"type"`
+	Text     *slackText   `json:"text,omitempty"`
+	Elements []*slackText `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Message with the main text, and optional details in smaller print
+func newSlackMessage(responseType, text string, details ...string) slackMessage {
+	msg := slackMessage{
+		ResponseType: responseType,
+		Text:         text,
+		Blocks:       []slackBlock{{Type: "section", Text: &slackText{Type: "mrkdwn", Text: text}}},
+	}
+	var context []*slackText
+	for _, detail := range details {
+		if detail != "" {
+			context = append(context, &slackText{Type: "mrkdwn", Text: detail})
+		}
+	}
+	if len(context) > 0 {
+		msg.Blocks = append(msg.Blocks, slackBlock{Type: "context", Elements: context})
+	}
+	return msg
+}
+
+// Characters with a special meaning in Slack messages
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// Handle a slash command such as `/standup next`, configured in Slack to post
+// to this endpoint
+func (srv *server) handleSlackCommand(w http.ResponseWriter, r *http.Request) {
+	if srv.slack.signingSecret == "" {
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: "Slack commands are not enabled: set SLACK_SIGNING_SECRET"})
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 1<<20))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: fmt.Sprintf("invalid request body: %v", err)})
+		return
+	}
+	if err := srv.slack.verify(r.Header, body); err != nil {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: err.Error()})
+		return
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: fmt.Sprintf("invalid request body: %v", err)})
+		return
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	// Slack shows any error as-is to the caller, so always answer with a message
+	writeJSON(w, http.StatusOK, srv.runSlackCommand(form.Get("text")))
+}
+
+func (srv *server) runSlackCommand(text string) slackMessage {
+	lang := srv.session.lang
+	command, arg, _ := strings.Cut(strings.TrimSpace(text), " ")
+	arg = strings.TrimSpace(arg)
+	cannot := func(action string, err error) slackMessage {
+		return newSlackMessage("ephemeral", slackEscaper.Replace(lang.cannot(action, err)))
+	}
+	bold := func(name string) string {
+		return "*" + slackEscaper.Replace(name) + "*"
+	}
+
+	switch strings.ToLower(command) {
+	case "next", "pick", "p":
+		result, err := srv.session.Pick(arg)
+		if err != nil {
+			return cannot("pick", err)
+		}
+		text := ":dart: " + lang.T("pick", bold(result.Name))
+		if result.Action == picker.ActionManualPick {
+			text += " " + lang.T("manualPick")
+		}
+		newRound := ""
+		if result.NewRound {
+			newRound = lang.T("chat.newRound")
+		}
+		return newSlackMessage("in_channel", text, newRound, slackRemaining(lang, result.Remaining), slackOnDeck(lang, result.Remaining))
+
+	case "skip", "k":
+		result, err := srv.session.Skip(arg)
+		if err != nil {
+			return cannot("skip", err)
+		}
+		return newSlackMessage("in_channel",
+			":fast_forward: "+lang.T("chat.skipped", bold(result.Name)),
+			slackOnDeck(lang, result.Remaining))
+
+	case "absent", "a":
+		result, err := srv.session.MarkAbsent(arg)
+		if err != nil {
+			return cannot("mark absent", err)
+		}
+		return newSlackMessage("in_channel",
+			":palm_tree: "+lang.T("chat.absent", bold(result.Name)),
+			slackRemaining(lang, result.Remaining))
+
+	case "undo", "u":
+		result, err := srv.session.Undo()
+		if err != nil {
+			return cannot("undo", err)
+		}
+		return newSlackMessage("in_channel",
+			":leftwards_arrow_with_hook: "+lang.T("chat."+undoMessage(result.Action), bold(result.Name)),
+			slackOnDeck(lang, result.Remaining))
+
+	case "reset", "r":
+		if err := srv.session.Reset(); err != nil {
+			return cannot("reset", err)
+		}
+		return newSlackMessage("in_channel", ":arrows_counterclockwise: "+lang.T("chat.reset"))
+
+	case "status", "s", "":
+		status, err := srv.session.Status()
+		if err != nil {
+			return cannot("show status", err)
+		}
+		if len(status.Remaining) == 0 {
+			return newSlackMessage("ephemeral", lang.T("status.everyonePicked"))
+		}
+		lines := make([]string, len(status.Remaining))
+		for i, name := range status.Remaining {
+			lines[i] = fmt.Sprintf("%d. %s", i+1, slackEscaper.Replace(name))
+		}
+		return newSlackMessage("ephemeral",
+			"*"+lang.T("chat.status", len(status.Remaining), status.TeamMembers+len(status.Guests))+"*\n"+strings.Join(lines, "\n"))
+
+	case "help", "h":
+		return newSlackMessage("ephemeral", lang.T("chat.help"))
+
+	default:
+		return newSlackMessage("ephemeral",
+			lang.T("chat.unknownCommand", slackEscaper.Replace(command), lang.T("chat.help")))
+	}
+}
+
+func slackRemaining(lang *language, remaining []string) string {
+	if len(remaining) == 0 {
+		return lang.T("lastPerson")
+	}
+	return lang.T("remaining", len(remaining))
+}
+
+func slackOnDeck(lang *language, remaining []string) string {
+	if len(remaining) == 0 {
+		return ""
+	}
+	onDeck := remaining[:min(onDeckCount, len(remaining))]
+	names := make([]string, len(onDeck))
+	for i, name := range onDeck {
+		names[i] = slackEscaper.Replace(name)
+	}
+	return lang.T("onDeck", strings.Join(names, ", "))
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSigningSecret = "8f742231b10e8888abcd99yyyzzz85a5"
+
+// Simulate Slack posting a slash command, signed with the given secret at the given time
+func newSlackRequest(t *testing.T, target, secret, text string, at time.Time) *http.Request {
+	t.Helper()
+	body := url.Values{
+		"command":   {"/standup"},
+		"text":      {text},
+		"user_name": {"alice"},
+	}.Encode()
+
+	req, err := http.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", slackSignature(secret, timestamp, []byte(body)))
+	return req
+}
+
+func newSlackTestServer(t *testing.T, secret string, teamMembers ...string) *httptest.Server {
+	t.Helper()
+	s := newTestSession(t, teamMembers...)
+	setTestRound(t, s, teamMembers...)
+	srv := newServer(s)
+	srv.slack = newSlackVerifier(secret)
+	ts := httptest.NewServer(srv.routes())
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// Send a slash command and decode the Slack message, returning the status code
+func sendSlackCommand(t *testing.T, req *http.Request) (int, slackMessage) {
+	t.Helper()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			t.Logf("Warning: failed to close response body: %v", err)
+		}
+	}()
+
+	var msg slackMessage
+	if resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(&msg); err != nil {
+			t.Fatalf("Failed to decode Slack message: %v", err)
+		}
+	}
+	return resp.StatusCode, msg
+}
+
+func TestSlackVerifier(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	v := newSlackVerifier(testSigningSecret)
+	v.now = func() time.Time { return now }
+
+	tests := []struct {
+		name     string
+		secret   string
+		at       time.Time
+		expected error
+	}{
+		{name: "valid", secret: testSigningSecret, at: now},
+		{name: "slightly late", secret: testSigningSecret, at: now.Add(-4 * time.Minute)},
+		{name: "wrong secret", secret: "other", at: now, expected: errSlackSignature},
+		{name: "replayed", secret: testSigningSecret, at: now.Add(-10 * time.Minute), expected: errSlackTimestamp},
+		{name: "from the future", secret: testSigningSecret, at: now.Add(10 * time.Minute), expected: errSlackTimestamp},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newSlackRequest(t, "http://localhost/slack/command", tt.secret, "next", tt.at)
+			body := []byte(url.Values{"command": {"/standup"}, "text": {"next"}, "user_name": {"alice"}}.Encode())
+			if err := v.verify(req.Header, body); err != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+
+	missing := http.Header{"X-Slack-Signature": {"v0=abc"}}
+	if err := v.verify(missing, nil); err != errSlackTimestamp {
+		t.Errorf("Expected errSlackTimestamp without a timestamp, got %v", err)
+	}
+}
+
+func TestServer_SlackCommand(t *testing.T) {
+	ts := newSlackTestServer(t, testSigningSecret, "Alice", "Bob", "Charlie")
+	endpoint := ts.URL + "/slack/command"
+
+	status, msg := sendSlackCommand(t, newSlackRequest(t, endpoint, testSigningSecret, "next", time.Now()))
+	if status != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", status)
+	}
+	if msg.ResponseType != "in_channel" || !strings.Contains(msg.Text, "*Alice*") {
+		t.Errorf("Expected Alice to be announced in the channel, got %+v", msg)
+	}
+	if len(msg.Blocks) != 2 || msg.Blocks[1].Type != "context" || msg.Blocks[1].Elements[1].Text != "On deck: Bob, Charlie" {
+		t.Errorf("Expected the remaining count and on deck names as context, got %+v", msg.Blocks)
+	}
+
+	_, msg = sendSlackCommand(t, newSlackRequest(t, endpoint, testSigningSecret, "next char", time.Now()))
+	if !strings.Contains(msg.Text, "*Charlie* (manual pick)") {
+		t.Errorf("Expected a manual pick of Charlie, got %q", msg.Text)
+	}
+
+	_, msg = sendSlackCommand(t, newSlackRequest(t, endpoint, testSigningSecret, "status", time.Now()))
+	if msg.ResponseType != "ephemeral" || !strings.Contains(msg.Text, "1. Bob") {
+		t.Errorf("Expected the status to be shown to the caller only, got %+v", msg)
+	}
+
+	_, msg = sendSlackCommand(t, newSlackRequest(t, endpoint, testSigningSecret, "next Zoe", time.Now()))
+	if msg.ResponseType != "ephemeral" || !strings.Contains(msg.Text, "Cannot pick") {
+		t.Errorf("Expected an error shown to the caller only, got %+v", msg)
+	}
+
+	_, msg = sendSlackCommand(t, newSlackRequest(t, endpoint, testSigningSecret, "dance", time.Now()))
+	if !strings.Contains(msg.Text, "Unknown command") {
+		t.Errorf("Expected an unknown command message, got %q", msg.Text)
+	}
+
+	if status, _ := sendSlackCommand(t, newSlackRequest(t, endpoint, "forged", "reset", time.Now())); status != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for a forged request, got %d", status)
+	}
+}
+
+func TestServer_SlackCommandDisabledWithoutSecret(t *testing.T) {
+	ts := newSlackTestServer(t, "", "Alice")
+	req := newSlackRequest(t, ts.URL+"/slack/command", "", "next", time.Now())
+	if status, _ := sendSlackCommand(t, req); status != http.StatusNotFound {
+		t.Errorf("Expected 404 without a signing secret, got %d", status)
+	}
+}
+
+func TestSlackMessagesInSessionLanguage(t *testing.T) {
+	s := newTestSession(t, "Alice", "Bob")
+	setTestRound(t, s, "Alice", "Bob")
+	srv := newServer(s)
+
+	msg := srv.runSlackCommand("next")
+	if len(msg.Blocks) != 2 || msg.Blocks[1].Elements[0].Text != "1 person remaining in this round" {
+		t.Errorf("Expected a singular remaining count, got %+v", msg.Blocks)
+	}
+
+	s.lang = french
+	msg = srv.runSlackCommand("next")
+	if msg.Text != ":dart: Au tour de... *Bob*" {
+		t.Errorf("Expected the pick in French, got %q", msg.Text)
+	}
+}
+
+func TestSlackEscapesNames(t *testing.T) {
+	s := newTestSession(t, "<!channel> & co")
+	setTestRound(t, s, s.TeamMembers()...)
+	srv := newServer(s)
+
+	msg := srv.runSlackCommand("next")
+	if strings.Contains(msg.Text, "<!channel>") || !strings.Contains(msg.Text, "&lt;!channel&gt; &amp; co") {
+		t.Errorf("Expected the name to be escaped, got %q", msg.Text)
+	}
+}
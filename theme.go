@@ -0,0 +1,226 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Semantic roles of text output, colored by the theme
+const (
+	// Member picked or acted upon
+	roleName = "name"
+	// Count of members remaining in the round
+	roleRemaining = "remaining"
+	roleSuccess   = "success"
+	// Errors and warnings
+	roleWarning = "warning"
+	roleHeading = "heading"
+	// Secondary figures, e.g. the size of the team
+	roleInfo = "info"
+	// Numbers of lists and upcoming speakers
+	roleAccent = "accent"
+	// Keys in the help
+	roleCommand = "command"
+)
+
+var themeRoles = []string{roleName, roleRemaining, roleSuccess, roleWarning, roleHeading, roleInfo, roleAccent, roleCommand}
+
+const defaultThemeName = "default"
+
+const ansiReset = "\033[0m"
+
+// Built-in themes, as color specs (see parseColor) per role
+var themes = map[string]map[string]string{
+	defaultThemeName: {
+		roleName:      "bold blue",
+		roleRemaining: "bright-red",
+		roleSuccess:   "bold green",
+		roleWarning:   "bright-red",
+		roleHeading:   "bold blue",
+		roleInfo:      "18",
+		roleAccent:    "22",
+		roleCommand:   "bold magenta",
+	},
+	"high-contrast": {
+		roleName:      "bold underline bright-blue",
+		roleRemaining: "bold bright-red",
+		roleSuccess:   "bold bright-green",
+		roleWarning:   "bold bright-red",
+		roleHeading:   "bold underline",
+		roleInfo:      "bold",
+		roleAccent:    "bold bright-green",
+		roleCommand:   "bold bright-magenta",
+	},
+	// From the Solarized palette, with content tones readable on each background
+	"solarized-dark": {
+		roleName:      "bold #268bd2",
+		roleRemaining: "#cb4b16",
+		roleSuccess:   "#859900",
+		roleWarning:   "#dc322f",
+		roleHeading:   "bold #2aa198",
+		roleInfo:      "#93a1a1",
+		roleAccent:    "#b58900",
+		roleCommand:   "#d33682",
+	},
+	"solarized-light": {
+		roleName:      "bold #268bd2",
+		roleRemaining: "#cb4b16",
+		roleSuccess:   "#859900",
+		roleWarning:   "#dc322f",
+		roleHeading:   "bold #2aa198",
+		roleInfo:      "#586e75",
+		roleAccent:    "#b58900",
+		roleCommand:   "#d33682",
+	},
+	"monochrome": {
+		roleName:    "bold",
+		roleSuccess: "bold",
+		roleWarning: "bold",
+		roleHeading: "bold underline",
+		roleCommand: "bold",
+	},
+}
+
+// ANSI escape sequence of each role; roles without one are not styled
+type theme map[string]string
+
+// Theme file, overriding the colors of some roles of a built-in theme, e.g.
+//
+//	{"base": "solarized-dark", "colors": {"name": "bold 33", "info": "#93a1a1"}}
+type themeFile struct {
+	Base   string            `json:"base"`
+	Colors map[string]string `json:"colors"`
+}
+
+func themeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Load the named built-in theme (the default one if empty), with the colors
+// of file (if any) on top
+func loadTheme(name, file string) (theme, error) {
+	var overrides themeFile
+	if file != "" {
+		if err := readThemeFile(file, &overrides); err != nil {
+			return nil, err
+		}
+		if overrides.Base != "" {
+			name = overrides.Base
+		}
+	}
+	if name == "" {
+		name = defaultThemeName
+	}
+
+	specs, ok := themes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown theme '%s' (expected one of %s)", name, strings.Join(themeNames(), ", "))
+	}
+	specs = maps.Clone(specs)
+	maps.Copy(specs, overrides.Colors)
+
+	t := make(theme, len(specs))
+	for role, spec := range specs {
+		if !slices.Contains(themeRoles, role) {
+			return nil, fmt.Errorf("unknown color role '%s' (expected one of %s)", role, strings.Join(themeRoles, ", "))
+		}
+		sequence, err := parseColor(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid color for '%s': %w", role, err)
+		}
+		t[role] = sequence
+	}
+	return t, nil
+}
+
+func readThemeFile(file string, overrides *themeFile) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			fmt.Printf("Warning: failed to close file: %v\n", err)
+		}
+	}()
+
+	decoder := json.NewDecoder(f)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(overrides); err != nil {
+		return fmt.Errorf("invalid theme file '%s': %w", file, err)
+	}
+	return nil
+}
+
+// Names of the 8 standard ANSI colors, in order
+var colorNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// Parse a color spec into an ANSI escape sequence. A spec is made of
+// space-separated attributes (bold, dim, italic, underline) and at most one
+// color: a name (e.g. blue or bright-blue), a 256-color palette number or a
+// #rrggbb true color. An empty spec means no style.
+func parseColor(spec string) (string, error) {
+	var codes []string
+	hasColor := false
+	for _, token := range strings.Fields(strings.ToLower(spec)) {
+		var code string
+		switch token {
+		case "bold":
+			code = "1"
+		case "dim":
+			code = "2"
+		case "italic":
+			code = "3"
+		case "underline":
+			code = "4"
+		default:
+			var err error
+			if code, err = parseColorToken(token); err != nil {
+				return "", err
+			}
+			if hasColor {
+				return "", fmt.Errorf("more than one color in '%s'", spec)
+			}
+			hasColor = true
+		}
+		codes = append(codes, code)
+	}
+
+	if len(codes) == 0 {
+		return "", nil
+	}
+	return "\033[" + strings.Join(codes, ";") + "m", nil
+}
+
+func parseColorToken(token string) (string, error) {
+	if token == "purple" {
+		token = "magenta"
+	}
+	if i := slices.Index(colorNames, token); i >= 0 {
+		return strconv.Itoa(30 + i), nil
+	}
+	if name, ok := strings.CutPrefix(token, "bright-"); ok {
+		if i := slices.Index(colorNames, name); i >= 0 {
+			return strconv.Itoa(90 + i), nil
+		}
+	}
+	if n, err := strconv.Atoi(token); err == nil && n >= 0 && n <= 255 {
+		return "38;5;" + token, nil
+	}
+	if hex, ok := strings.CutPrefix(token, "#"); ok && len(hex) == 6 {
+		if rgb, err := strconv.ParseUint(hex, 16, 32); err == nil {
+			return fmt.Sprintf("38;2;%d;%d;%d", rgb>>16, rgb>>8&0xff, rgb&0xff), nil
+		}
+	}
+	return "", fmt.Errorf("unknown color '%s'", token)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected string
+		wantErr  bool
+	}{
+		{spec: "", expected: ""},
+		{spec: "bold blue", expected: "\033[1;34m"},
+		{spec: "Bright-Red", expected: "\033[91m"},
+		{spec: "purple", expected: "\033[35m"},
+		{spec: "underline 33", expected: "\033[4;38;5;33m"},
+		{spec: "#268bd2", expected: "\033[38;2;38;139;210m"},
+		{spec: "bold", expected: "\033[1m"},
+		{spec: "256", wantErr: true},
+		{spec: "#12345", wantErr: true},
+		{spec: "red blue", wantErr: true},
+		{spec: "sparkly", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := parseColor(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error: %v, got: %v", tt.wantErr, err)
+			}
+			if got != tt.expected {
+				t.Errorf("parseColor(%q) = %q; want %q", tt.spec, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBuiltInThemes(t *testing.T) {
+	for _, name := range themeNames() {
+		if _, err := loadTheme(name, ""); err != nil {
+			t.Errorf("Theme %s is invalid: %v", name, err)
+		}
+	}
+}
+
+func TestLoadTheme_File(t *testing.T) {
+	dir := t.TempDir()
+	writeTheme := func(content string) string {
+		file := filepath.Join(dir, "theme.json")
+		if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write theme: %v", err)
+		}
+		return file
+	}
+
+	th, err := loadTheme("monochrome", writeTheme(`{"colors": {"info": "cyan", "name": ""}}`))
+	if err != nil {
+		t.Fatalf("loadTheme failed: %v", err)
+	}
+	if th[roleInfo] != "\033[36m" || th[roleName] != "" || th[roleHeading] != "\033[1;4m" {
+		t.Errorf("Expected the file to override the monochrome theme, got %q", th)
+	}
+
+	th, err = loadTheme("", writeTheme(`{"base": "solarized-light", "colors": {"accent": "yellow"}}`))
+	if err != nil {
+		t.Fatalf("loadTheme failed: %v", err)
+	}
+	if th[roleAccent] != "\033[33m" || th[roleInfo] != "\033[38;2;88;110;117m" {
+		t.Errorf("Expected the file to override its base theme, got %q", th)
+	}
+
+	for _, content := range []string{
+		`{"colors": {"nmae": "red"}}`,
+		`{"colors": {"name": "sparkly"}}`,
+		`{"base": "neon"}`,
+		`{"colours": {}}`,
+	} {
+		if _, err := loadTheme("", writeTheme(content)); err == nil {
+			t.Errorf("Expected an error for theme file %s", content)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// Live view of the stand-up: renders the state streamed by /api/events and
+// sends the same actions as the terminal commands to the REST API.
+(function () {
+  "use strict";
+
+  const $ = (id) => document.getElementById(id);
+
+  // Access token of shared links, e.g. /?token=... for facilitators or viewers
+  const token = new URLSearchParams(window.location.search).get("token");
+  const authHeaders = token ? { Authorization: `Bearer ${token}` } : {};
+  const withToken = (url) => (token ? `${url}?token=${encodeURIComponent(token)}` : url);
+
+  let state = null;
+  // Local clock of the current speaker, kept in sync by the server ticks
+  let speakingSince = null;
+
+  function formatElapsed(seconds) {
+    const minutes = Math.floor(seconds / 60);
+    const rest = String(seconds % 60).padStart(2, "0");
+    return `${minutes}:${rest}`;
+  }
+
+  function renderTimer() {
+    const seconds = speakingSince ? Math.max(0, Math.floor((Date.now() - speakingSince) / 1000)) : 0;
+    $("timer").textContent = formatElapsed(seconds);
+  }
+
+  function memberItem(name, remaining) {
+    const item = document.createElement("li");
+    const label = document.createElement(remaining ? "button" : "span");
+    label.className = "name";
+    label.textContent = name;
+    item.appendChild(label);
+
+    if ((state.guests || []).includes(name)) {
+      const guest = document.createElement("span");
+      guest.className = "guest";
+      guest.textContent = "(guest)";
+      label.appendChild(guest);
+    }
+
+    if (remaining) {
+      label.title = `Pick ${name} next`;
+      label.addEventListener("click", () => send("pick", { name }));
+
+      const absent = document.createElement("button");
+      absent.className = "absent";
+      absent.textContent = "Absent";
+      absent.title = `${name} is away today`;
+      absent.addEventListener("click", () => send("absent", { name }));
+      item.appendChild(absent);
+    }
+    return item;
+  }
+
+  function render() {
+    const remaining = state.remaining || [];
+    const everyone = (state.members || []).concat(state.guests || []);
+
+    $("speaker").textContent = state.speaker || "Nobody yet";
+    speakingSince = state.speakingSince ? Date.parse(state.speakingSince) : null;
+    renderTimer();
+
+    $("next").textContent = remaining.length === 0 ? "New round" : "Next";
+    $("skip").disabled = remaining.length === 0;
+    $("progress").textContent = remaining.length === 0
+      ? "Everyone has been picked this round"
+      : `${remaining.length} of ${everyone.length} remaining`;
+
+    $("remaining").replaceChildren(...remaining.map((name) => memberItem(name, true)));
+    $("done").replaceChildren(
+      ...everyone.filter((name) => !remaining.includes(name)).map((name) => memberItem(name, false)),
+    );
+  }
+
+  async function send(action, body) {
+    $("error").textContent = "";
+    try {
+      const response = await fetch(`api/${action}`, {
+        method: "POST",
+        headers: { "Content-Type": "application/json", ...authHeaders },
+        body: body ? JSON.stringify(body) : "",
+      });
+      if (!response.ok) {
+        const payload = await response.json().catch(() => ({}));
+        $("error").textContent = payload.error || `${action} failed (${response.status})`;
+      }
+    } catch (err) {
+      $("error").textContent = `${action} failed: ${err.message}`;
+    }
+    // The resulting state comes back through the event stream
+  }
+
+  function connect() {
+    const events = new EventSource(withToken("api/events"));
+
+    events.addEventListener("open", () => {
+      $("connection").textContent = "Live";
+      $("connection").className = "online";
+    });
+    events.addEventListener("error", () => {
+      // EventSource reconnects on its own and gets the latest state back
+      $("connection").textContent = "Reconnecting...";
+      $("connection").className = "offline";
+    });
+
+    events.addEventListener("state", (e) => {
+      state = JSON.parse(e.data);
+      render();
+    });
+    for (const type of ["pick", "skip", "absent", "undo", "reset"]) {
+      events.addEventListener(type, (e) => {
+        state = JSON.parse(e.data).state;
+        render();
+      });
+    }
+    events.addEventListener("tick", (e) => {
+      const tick = JSON.parse(e.data);
+      const since = Date.now() - tick.elapsedSeconds * 1000;
+      // Only correct a drifting clock, to keep the timer from jittering
+      if (state && tick.speaker === state.speaker && Math.abs(since - (speakingSince || 0)) > 2000) {
+        speakingSince = since;
+        renderTimer();
+      }
+    });
+  }
+
+  // Viewers follow along without the controls
+  async function loadRole() {
+    try {
+      const response = await fetch("api/role", { headers: authHeaders });
+      const { role } = await response.json();
+      document.body.classList.toggle("read-only", role !== "facilitator");
+      if (role === "none") {
+        $("error").textContent = "Access denied: open the link shared by your facilitator, including its token.";
+      }
+    } catch (err) {
+      $("error").textContent = `Could not check access: ${err.message}`;
+    }
+  }
+
+  $("next").addEventListener("click", () => send("pick"));
+  $("skip").addEventListener("click", () => send("skip"));
+  $("undo").addEventListener("click", () => send("undo"));
+  $("reset").addEventListener("click", () => {
+    if (window.confirm("Reset the round and start over?")) {
+      send("reset");
+    }
+  });
+  document.addEventListener("keydown", (e) => {
+    if (e.key === "n" && !e.ctrlKey && !e.metaKey && !e.altKey && !document.body.classList.contains("read-only")) {
+      send("pick");
+    }
+  });
+
+  setInterval(renderTimer, 1000);
+  loadRole();
+  connect();
+})();
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rm3l/daily-scrum-picker/picker"
+)
+
+// Payload formats of outgoing webhooks
+const (
+	WebhookFormatJSON       = "json"
+	WebhookFormatSlack      = "slack"
+	WebhookFormatTeams      = "teams"
+	WebhookFormatMattermost = "mattermost"
+)
+
+const (
+	defaultWebhookTimeout = 5 * time.Second
+	defaultWebhookRetries = 3
+	// Delay before the first retry, doubled for each of the next ones
+	webhookBackoff = time.Second
+	// How long to wait for pending notifications when exiting
+	webhookFlushTimeout = 10 * time.Second
+)
+
+// Events posted when none are configured: picks, and resets of the round
+var defaultWebhookEvents = []string{picker.EventPick, picker.EventReset}
+
+// Where and how to post events, as set in the config file
+type webhookConfig struct {
+	URL    string `json:"url"`
+	Format string `json:"format,omitempty"`
+	// Extra request headers, e.g. for authentication
+	Headers map[string]string `json:"headers,omitempty"`
+	// Types of events to post, e.g. ["pick", "reset"]
+	Events  []string `json:"events,omitempty"`
+	Timeout duration `json:"timeout,omitempty"`
+	// Number of attempts after the first one fails
+	Retries *int `json:"retries,omitempty"`
+}
+
+func (c webhookConfig) validate() error {
+	u, err := url.Parse(c.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid URL '%s'", c.URL)
+	}
+	switch c.Format {
+	case "", WebhookFormatJSON, WebhookFormatSlack, WebhookFormatTeams, WebhookFormatMattermost:
+	default:
+		return fmt.Errorf("unknown format '%s' (expected json, slack, teams or mattermost)", c.Format)
+	}
+	if err := validateEventTypes(c.Events); err != nil {
+		return err
+	}
+	if c.Retries != nil && *c.Retries < 0 {
+		return errors.New("retries cannot be negative")
+	}
+	return nil
+}
+
+// Body of the default json format
+type webhookEvent struct {
+	picker.Event
+	// Room of the event in server mode with multiple teams
+	Room string `json:"room,omitempty"`
+}
+
+// Posts events to a webhook in the background, so that a slow or unreachable
+// endpoint never holds up the stand-up
+type webhook struct {
+	config  webhookConfig
+	room    string
+	lang    *language
+	client  *http.Client
+	retries int
+	backoff time.Duration
+	queue   chan picker.Event
+	done    chan struct{}
+}
+
+func newWebhook(config webhookConfig, room string, lang *language) *webhook {
+	timeout := time.Duration(config.Timeout)
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+	retries := defaultWebhookRetries
+	if config.Retries != nil {
+		retries = *config.Retries
+	}
+	if len(config.Events) == 0 {
+		config.Events = defaultWebhookEvents
+	}
+
+	w := &webhook{
+		config:  config,
+		room:    room,
+		lang:    lang,
+		client:  &http.Client{Timeout: timeout},
+		retries: retries,
+		backoff: webhookBackoff,
+		queue:   make(chan picker.Event, 64),
+		done:    make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Post the events configured in cfg from the session to its webhooks, until
+// the session is closed
+func (s *session) notifyWebhooks(cfg config, room string) {
+	if len(cfg.Webhooks) == 0 {
+		return
+	}
+	webhooks := make([]*webhook, len(cfg.Webhooks))
+	for i, webhookCfg := range cfg.Webhooks {
+		webhooks[i] = newWebhook(webhookCfg, room, s.lang)
+	}
+
+	s.Subscribe(func(event picker.Event) {
+		for _, w := range webhooks {
+			w.enqueue(event)
+		}
+	})
+	s.onClose(func() {
+		deadline := time.After(webhookFlushTimeout)
+		for _, w := range webhooks {
+			w.close()
+		}
+		for _, w := range webhooks {
+			select {
+			case <-w.done:
+			case <-deadline:
+				log.Printf("Warning: gave up waiting for webhook %s", w.config.URL)
+				return
+			}
+		}
+	})
+}
+
+func (w *webhook) enqueue(event picker.Event) {
+	if !slices.Contains(w.config.Events, event.Type) {
+		return
+	}
+	select {
+	case w.queue <- event:
+	default:
+		log.Printf("Warning: too many pending events for webhook %s, dropping %s event", w.config.URL, event.Type)
+	}
+}
+
+// Stop accepting events; done is closed once the pending ones are sent
+func (w *webhook) close() {
+	close(w.queue)
+}
+
+func (w *webhook) run() {
+	defer close(w.done)
+	for event := range w.queue {
+		if err := w.deliver(event); err != nil {
+			log.Printf("Warning: webhook %s failed for %s event: %v", w.config.URL, event.Type, err)
+		}
+	}
+}
+
+// Post the event, retrying with an exponential backoff on network errors,
+// rate limiting and server errors
+func (w *webhook) deliver(event picker.Event) error {
+	body, err := webhookBody(w.config.Format, event, w.room, w.lang)
+	if err != nil {
+		return err
+	}
+
+	backoff := w.backoff
+	for attempt := 0; ; attempt++ {
+		retryable, err := w.post(body)
+		if err == nil || !retryable || attempt >= w.retries {
+			return err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (w *webhook) post(body []byte) (retryable bool, err error) {
+	req, err := http.NewRequest(http.MethodPost, w.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "daily-scrum-picker")
+	for name, value := range w.config.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	if err := resp.Body.Close(); err != nil {
+		log.Printf("Warning: failed to close webhook response body: %v", err)
+	}
+
+	switch {
+	case resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode >= 500:
+		return true, fmt.Errorf("unexpected status %s", resp.Status)
+	default:
+		return false, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+}
+
+// Request body of the event in the given format
+func webhookBody(format string, event picker.Event, room string, lang *language) ([]byte, error) {
+	switch format {
+	case WebhookFormatSlack:
+		// https://api.slack.com/messaging/webhooks
+		return json.Marshal(map[string]string{"text": describeEvent(event, room, "*", lang)})
+	case WebhookFormatMattermost:
+		// https://developers.mattermost.com/integrate/webhooks/incoming/
+		return json.Marshal(map[string]string{"text": describeEvent(event, room, "**", lang)})
+	case WebhookFormatTeams:
+		// Message card of Microsoft Teams incoming webhooks
+		text := describeEvent(event, room, "**", lang)
+		return json.Marshal(map[string]string{
+			"@type":    "MessageCard",
+			"@context": "https://schema.org/extensions",
+			"summary":  text,
+			"text":     text,
+		})
+	default:
+		return json.Marshal(webhookEvent{Event: event, Room: room})
+	}
+}
+
+// Human-readable description of an event for chat messages, in the language
+// of the session, with names in bold using the given markdown marker. Names
+// are escaped so that they cannot mention users or inject links.
+func describeEvent(event picker.Event, room, bold string, lang *language) string {
+	name := bold + slackEscaper.Replace(event.Name) + bold
+	var text string
+	switch event.Type {
+	case picker.EventPick:
+		text = "🎯 " + lang.T("pick", name)
+		if event.NewRound {
+			text = "🔄 " + lang.T("chat.newRound") + " " + text
+		}
+		if len(event.Remaining) == 0 {
+			text += " (" + lang.T("chat.lastPerson") + ")"
+		} else {
+			onDeck := event.Remaining[:min(onDeckCount, len(event.Remaining))]
+			text += " (" + lang.T("chat.remaining", len(event.Remaining),
+				slackEscaper.Replace(strings.Join(onDeck, ", "))) + ")"
+		}
+	case picker.EventSkip:
+		text = "⏩ " + lang.T("chat.skipped", name)
+	case picker.EventAbsent:
+		text = "🌴 " + lang.T("chat.absent", name)
+	case picker.EventUndo:
+		text = "↩️ " + lang.T("chat."+undoMessage(event.Action), name)
+	case picker.EventReset:
+		text = "🔄 " + lang.T("chat.reset")
+	case picker.EventRoundComplete:
+		text = "✅ " + lang.T("chat.roundComplete")
+	case picker.EventMeetingEnd:
+		text = "👋 " + lang.T("chat.meetingEnd")
+	default:
+		text = event.Type
+	}
+	if room != "" {
+		text = fmt.Sprintf("[%s] %s", slackEscaper.Replace(room), text)
+	}
+	return text
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rm3l/daily-scrum-picker/picker"
+)
+
+// Records the requests posted to a test webhook endpoint, failing the first
+// ones with the given statuses
+type webhookRecorder struct {
+	mu       sync.Mutex
+	statuses []int
+	bodies   [][]byte
+	headers  []http.Header
+}
+
+func (rec *webhookRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.bodies = append(rec.bodies, body)
+	rec.headers = append(rec.headers, r.Header.Clone())
+	if len(rec.statuses) > 0 {
+		status := rec.statuses[0]
+		rec.statuses = rec.statuses[1:]
+		w.WriteHeader(status)
+	}
+}
+
+func (rec *webhookRecorder) requests() ([][]byte, []http.Header) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	return rec.bodies, rec.headers
+}
+
+func newWebhookTestServer(t *testing.T, statuses ...int) (*httptest.Server, *webhookRecorder) {
+	t.Helper()
+	rec := &webhookRecorder{statuses: statuses}
+	ts := httptest.NewServer(rec)
+	t.Cleanup(ts.Close)
+	return ts, rec
+}
+
+func TestWebhookPostsPicksAndResets(t *testing.T) {
+	ts, rec := newWebhookTestServer(t)
+	s := newTestSession(t, "Alice", "Bob")
+	setTestRound(t, s, "Alice", "Bob")
+	s.notifyWebhooks(config{Webhooks: []webhookConfig{{
+		URL:     ts.URL,
+		Headers: map[string]string{"Authorization": "Bearer s3cret"},
+	}}}, "payments")
+
+	if _, err := s.Pick("Bob"); err != nil {
+		t.Fatalf("Pick failed: %v", err)
+	}
+	// Skips are not posted by default
+	if _, err := s.Skip(""); err != nil {
+		t.Fatalf("Skip failed: %v", err)
+	}
+	if err := s.Reset(); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	s.close()
+
+	bodies, headers := rec.requests()
+	if len(bodies) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(bodies))
+	}
+	var pick webhookEvent
+	if err := json.Unmarshal(bodies[0], &pick); err != nil {
+		t.Fatalf("Invalid payload: %v", err)
+	}
+	if pick.Type != picker.EventPick || pick.Name != "Bob" || pick.Room != "payments" {
+		t.Errorf("Unexpected pick event: %+v", pick)
+	}
+	if got := headers[0].Get("Authorization"); got != "Bearer s3cret" {
+		t.Errorf("Expected custom header to be sent, got %q", got)
+	}
+	if got := headers[0].Get("Content-Type"); got != "application/json" {
+		t.Errorf("Expected JSON content type, got %q", got)
+	}
+	var reset webhookEvent
+	if err := json.Unmarshal(bodies[1], &reset); err != nil {
+		t.Fatalf("Invalid payload: %v", err)
+	}
+	if reset.Type != picker.EventReset {
+		t.Errorf("Expected a reset event, got %+v", reset)
+	}
+}
+
+func TestWebhookRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		retries  int
+		attempts int
+		failed   bool
+	}{
+		{name: "success", attempts: 1, retries: 2},
+		{name: "server error then success", statuses: []int{500, 503}, retries: 2, attempts: 3},
+		{name: "rate limited then success", statuses: []int{429}, retries: 2, attempts: 2},
+		{name: "gives up after retries", statuses: []int{500, 500, 500}, retries: 2, attempts: 3, failed: true},
+		{name: "client errors are not retried", statuses: []int{404}, retries: 2, attempts: 1, failed: true},
+		{name: "no retries", statuses: []int{500}, retries: 0, attempts: 1, failed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, rec := newWebhookTestServer(t, tt.statuses...)
+			w := &webhook{
+				config:  webhookConfig{URL: ts.URL},
+				client:  ts.Client(),
+				retries: tt.retries,
+				backoff: time.Millisecond,
+			}
+
+			err := w.deliver(picker.Event{Type: picker.EventPick, Name: "Alice"})
+			if (err != nil) != tt.failed {
+				t.Errorf("Expected failure: %v, got error: %v", tt.failed, err)
+			}
+			if bodies, _ := rec.requests(); len(bodies) != tt.attempts {
+				t.Errorf("Expected %d attempts, got %d", tt.attempts, len(bodies))
+			}
+		})
+	}
+}
+
+func TestWebhookTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	t.Cleanup(ts.Close)
+
+	retries := 0
+	w := newWebhook(webhookConfig{URL: ts.URL, Timeout: duration(20 * time.Millisecond), Retries: &retries}, "", english)
+	defer w.close()
+	if err := w.deliver(picker.Event{Type: picker.EventPick, Name: "Alice"}); err == nil {
+		t.Error("Expected the request to time out")
+	}
+}
+
+func TestWebhookBody(t *testing.T) {
+	event := picker.Event{Type: picker.EventPick, Name: "Alice", Remaining: []string{"Bob", "Charlie"}}
+
+	tests := []struct {
+		format   string
+		key      string
+		contains string
+	}{
+		{format: WebhookFormatSlack, key: "text", contains: "*Alice*"},
+		{format: WebhookFormatMattermost, key: "text", contains: "**Alice**"},
+		{format: WebhookFormatTeams, key: "text", contains: "**Alice**"},
+		{format: WebhookFormatTeams, key: "@type", contains: "MessageCard"},
+		{format: WebhookFormatJSON, key: "name", contains: "Alice"},
+		{format: "", key: "type", contains: picker.EventPick},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format+"/"+tt.key, func(t *testing.T) {
+			body, err := webhookBody(tt.format, event, "payments", english)
+			if err != nil {
+				t.Fatalf("Failed to build body: %v", err)
+			}
+			var payload map[string]any
+			if err := json.Unmarshal(body, &payload); err != nil {
+				t.Fatalf("Invalid JSON: %v", err)
+			}
+			value, _ := payload[tt.key].(string)
+			if !strings.Contains(value, tt.contains) {
+				t.Errorf("Expected %s to contain %q, got %q", tt.key, tt.contains, value)
+			}
+		})
+	}
+}
+
+func TestDescribeEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    picker.Event
+		room     string
+		expected string
+	}{
+		{
+			name:     "pick",
+			event:    picker.Event{Type: picker.EventPick, Name: "Alice", Remaining: []string{"Bob"}},
+			expected: "🎯 Next is... *Alice* (1 remaining, on deck: Bob)",
+		},
+		{
+			name:     "last pick in a room",
+			event:    picker.Event{Type: picker.EventPick, Name: "Alice", Remaining: []string{}},
+			room:     "payments",
+			expected: "[payments] 🎯 Next is... *Alice* (that was the last person in this round)",
+		},
+		{
+			name:     "names are escaped",
+			event:    picker.Event{Type: picker.EventSkip, Name: "<!channel>"},
+			expected: "⏩ Skipped *&lt;!channel&gt;*, moved to the end of the round",
+		},
+		{
+			name:     "on deck names are escaped",
+			event:    picker.Event{Type: picker.EventPick, Name: "Alice", Remaining: []string{"<https://example.com|Bob>"}},
+			expected: "🎯 Next is... *Alice* (1 remaining, on deck: &lt;https://example.com|Bob&gt;)",
+		},
+		{
+			name:     "reset",
+			event:    picker.Event{Type: picker.EventReset},
+			expected: "🔄 The round was reset, everyone gets a turn again",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := describeEvent(tt.event, tt.room, "*", english); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+
+	event := picker.Event{Type: picker.EventPick, Name: "Alice", Remaining: []string{"Bob", "Charlie"}}
+	if got := describeEvent(event, "", "*", french); got != "🎯 Au tour de... *Alice* (2 restants, ensuite : Bob, Charlie)" {
+		t.Errorf("Expected the event in French, got %q", got)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{name: "valid", content: `{"webhooks": [{"url": "https://example.com/hook", "format": "slack", "timeout": "2s", "retries": 1, "events": ["pick"]}]}`},
+		{name: "empty", content: `{}`},
+		{name: "invalid URL", content: `{"webhooks": [{"url": "example.com"}]}`, wantErr: true},
+		{name: "unknown format", content: `{"webhooks": [{"url": "https://example.com", "format": "discord"}]}`, wantErr: true},
+		{name: "unknown event", content: `{"webhooks": [{"url": "https://example.com", "events": ["lunch"]}]}`, wantErr: true},
+		{name: "invalid timeout", content: `{"webhooks": [{"url": "https://example.com", "timeout": 5}]}`, wantErr: true},
+		{name: "negative retries", content: `{"webhooks": [{"url": "https://example.com", "retries": -1}]}`, wantErr: true},
+		{name: "unknown field", content: `{"webhook": []}`, wantErr: true},
+		{name: "theme", content: `{"theme": "solarized-dark"}`},
+		{name: "unknown theme", content: `{"theme": "neon"}`, wantErr: true},
+		{name: "messages", content: `{"messages": {"pick": "🎤 Over to you, {{.Name}}!"}}`},
+		{name: "invalid message", content: `{"messages": {"pick": "{{.Nmae}}"}}`, wantErr: true},
+		{name: "missing theme file", content: `{"themeFile": "missing.json"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configFile := filepath.Join(t.TempDir(), "config.json")
+			if err := os.WriteFile(configFile, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			_, err := loadConfig(configFile)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error: %v, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if cfg, err := loadConfig(""); err != nil || len(cfg.Webhooks) != 0 {
+		t.Errorf("Expected an empty config without a file, got %+v, %v", cfg, err)
+	}
+}
+
+func TestGetConfigFile(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "/env/config.json")
+	if got := getConfigFile(""); got != "/env/config.json" {
+		t.Errorf("Expected the environment variable to be used, got %q", got)
+	}
+	if got := getConfigFile("/flag/config.json"); got != "/flag/config.json" {
+		t.Errorf("Expected the flag to take precedence, got %q", got)
+	}
+}